@@ -0,0 +1,142 @@
+package logging
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Accumulator adds up counts and sums over a window and logs them as a single
+// Info line per window instead of a line per occurrence. All methods are safe
+// for concurrent use. Example:
+//
+//	acc := svc.Accumulator("spots", time.Minute)
+//	acc.Inc("received")
+//	acc.AddFloat("bytes", float64(n))
+type Accumulator struct {
+	service *Service
+	name    string
+	window  time.Duration
+
+	mu     sync.Mutex
+	values map[string]float64
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Accumulator returns an accumulator called name that logs and resets its
+// values every flushEvery (if > 0), and a final time when it is stopped or the
+// service is closed. Windows without any value are not logged.
+func (s *Service) Accumulator(name string, flushEvery time.Duration) *Accumulator {
+	a := &Accumulator{
+		service: s,
+		name:    name,
+		window:  flushEvery,
+		values:  make(map[string]float64),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if s != nil {
+		s.accMu.Lock()
+		if s.accumulators == nil {
+			s.accumulators = make(map[*Accumulator]struct{})
+		}
+		s.accumulators[a] = struct{}{}
+		s.accMu.Unlock()
+	}
+	if flushEvery > 0 {
+		go a.flushLoop()
+	} else {
+		close(a.done)
+	}
+	return a
+}
+
+// flushLoop logs the accumulated values on each tick until stopped.
+func (a *Accumulator) flushLoop() {
+	defer close(a.done)
+	ticker := time.NewTicker(a.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.Flush()
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+// Inc adds one to the count for key.
+func (a *Accumulator) Inc(key string) {
+	a.AddFloat(key, 1)
+}
+
+// AddFloat adds v to the sum for key.
+func (a *Accumulator) AddFloat(key string, v float64) {
+	a.mu.Lock()
+	a.values[key] += v
+	a.mu.Unlock()
+}
+
+// Flush logs the accumulated values now and resets them. The Info line carries
+// accumulator (the name), window_ms (the flush interval, if any) and one field
+// per key, in key order.
+func (a *Accumulator) Flush() {
+	a.mu.Lock()
+	values := a.values
+	if len(values) == 0 {
+		a.mu.Unlock()
+		return
+	}
+	a.values = make(map[string]float64, len(values))
+	a.mu.Unlock()
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	e := a.service.InfoWith().Str("accumulator", a.name)
+	if a.window > 0 {
+		e = e.Int64("window_ms", a.window.Milliseconds())
+	}
+	for _, k := range keys {
+		e = e.Float64(k, values[k])
+	}
+	e.Msg("accumulator summary")
+}
+
+// Stop stops the flush ticker and logs the values accumulated since the last
+// flush. Values added afterwards are only logged by an explicit Flush. It is
+// safe to call multiple times.
+func (a *Accumulator) Stop() {
+	a.stopOnce.Do(func() {
+		close(a.stop)
+		<-a.done
+		a.Flush()
+		if a.service != nil {
+			a.service.accMu.Lock()
+			delete(a.service.accumulators, a)
+			a.service.accMu.Unlock()
+		}
+	})
+}
+
+// stopAccumulators stops every accumulator of the service, writing their final
+// summaries while the logger is still available.
+func (s *Service) stopAccumulators() {
+	s.accMu.Lock()
+	accs := make([]*Accumulator, 0, len(s.accumulators))
+	for a := range s.accumulators {
+		accs = append(accs, a)
+	}
+	s.accMu.Unlock()
+	sort.Slice(accs, func(i, j int) bool { return accs[i].name < accs[j].name })
+	for _, a := range accs {
+		a.Stop()
+	}
+}
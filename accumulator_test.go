@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccumulator_FlushesOnTick(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	// Creates the log file so that it can be polled below
+	service.InfoWith().Msg("start")
+	acc := service.Accumulator("spots", 300*time.Millisecond)
+	defer acc.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				acc.Inc("received")
+			}
+			acc.Inc("rejected")
+			acc.AddFloat("bytes", 1.5)
+		}()
+	}
+	wg.Wait()
+
+	var entries []logEntry
+	require.Eventually(t, func() bool {
+		entries = readLogEntries(t, dir, logFileName(service))
+		return len(entries) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	summary := entries[1]
+	assert.Equal(t, "info", summary["level"])
+	assert.Equal(t, "accumulator summary", summary["message"])
+	assert.Equal(t, "spots", summary["accumulator"])
+	assert.Equal(t, float64(300), summary["window_ms"])
+	assert.Equal(t, float64(20), summary["received"])
+	assert.Equal(t, float64(4), summary["rejected"])
+	assert.Equal(t, float64(6), summary["bytes"])
+
+	// Values were reset and empty windows are not logged
+	time.Sleep(700 * time.Millisecond)
+	assert.Len(t, readLogEntries(t, dir, logFileName(service)), 2)
+}
+
+func TestAccumulator_FinalEmitOnClose(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	acc := service.Accumulator("qsos", time.Hour)
+	acc.Inc("logged")
+	acc.Inc("logged")
+	manual := service.Accumulator("manual", 0)
+	manual.AddFloat("seconds", 0.25)
+
+	name := logFileName(service)
+	require.NoError(t, service.Close())
+	acc.Stop()
+
+	entries := readLogEntries(t, dir, name)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "manual", entries[0]["accumulator"])
+	assert.Equal(t, 0.25, entries[0]["seconds"])
+	assert.NotContains(t, entries[0], "window_ms")
+	assert.Equal(t, "qsos", entries[1]["accumulator"])
+	assert.Equal(t, float64(2), entries[1]["logged"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestAccumulator_ExplicitFlush(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	acc := service.Accumulator("manual", 0)
+	defer acc.Stop()
+
+	acc.Flush()
+	acc.Inc("a")
+	acc.Flush()
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, float64(1), entries[0]["a"])
+}
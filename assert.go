@@ -0,0 +1,26 @@
+package logging
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Assert logs an invariant violation and continues: when cond is false it emits
+// a Warn line with assertion_failed=true, the caller's file:line as
+// assert_caller, msg, and any fields added by the fields function (which may be
+// nil). When cond is true it returns immediately without creating an event.
+// Example: svc.Assert(n >= 0, "negative count", func(e LogEvent) { e.Int("n", n) })
+func (s *Service) Assert(cond bool, msg string, fields func(LogEvent)) {
+	if cond || s == nil || !s.isInitialized.Load() {
+		return
+	}
+
+	event := s.WarnWith().Bool("assertion_failed", true)
+	if _, file, line, ok := runtime.Caller(1); ok {
+		event = event.Str("assert_caller", fmt.Sprintf("%s:%d", file, line))
+	}
+	if fields != nil {
+		fields(event)
+	}
+	event.Msg(msg)
+}
@@ -0,0 +1,41 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Assert(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	called := false
+	service.Assert(true, "never logged", func(LogEvent) { called = true })
+	assert.False(t, called)
+	assert.Equal(t, int32(0), service.PeakActiveOperations())
+
+	service.Assert(1 > 2, "ordering violated", func(e LogEvent) {
+		e.Int("a", 1).Int("b", 2)
+	})
+	service.Assert(false, "without fields", nil)
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "warn", entries[0]["level"])
+	assert.Equal(t, true, entries[0]["assertion_failed"])
+	assert.Equal(t, "ordering violated", entries[0]["message"])
+	assert.Equal(t, float64(1), entries[0]["a"])
+	assert.True(t, strings.Contains(entries[0]["assert_caller"].(string), "assert_test.go:"))
+	assert.Equal(t, "without fields", entries[1]["message"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestService_AssertTrueDoesNotAllocate(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+	allocs := testing.AllocsPerRun(100, func() {
+		service.Assert(true, "ok", nil)
+	})
+	assert.Zero(t, allocs)
+}
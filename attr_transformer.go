@@ -0,0 +1,53 @@
+package logging
+
+// SetAttrTransformer installs fn, similar to slog's ReplaceAttr, to normalize
+// the fields added through LogEvent's typed methods (e.g. renaming user to
+// user_id). fn returns the key and value to write: an empty key drops the
+// field, and a value of another type is written like Interface. Error fields
+// from Err/AnErr, their enrichment, nested Dict fields and context logger
+// fields are not transformed. fn runs on the logging goroutine and must be safe
+// for concurrent use. Passing nil removes it.
+func (s *Service) SetAttrTransformer(fn func(key string, val interface{}) (string, interface{})) {
+	if s == nil {
+		return
+	}
+	if fn == nil {
+		s.attrTransformer.Store(nil)
+		return
+	}
+	s.attrTransformer.Store(&fn)
+}
+
+// transformAttr applies the service's attribute transformer, if any, and the
+// StrictFieldKeys guard to a field. It returns ok=false when the field was
+// dropped or has already been written because the transformer changed its type;
+// otherwise the caller writes the returned key and value with its own typed method.
+func transformAttr[T any](e *logEvent, key string, val T) (string, T, bool) {
+	if e.service == nil {
+		return key, val, true
+	}
+	t := e.service.attrTransformer.Load()
+	if t == nil {
+		return e.service.guardFieldKey(key), val, true
+	}
+	k, v := (*t)(key, val)
+	if k == emptyString {
+		return k, val, false
+	}
+	k = e.service.guardFieldKey(k)
+	if tv, ok := v.(T); ok {
+		return k, tv, true
+	}
+	e.interfaceField(k, v)
+	return k, val, false
+}
+
+// interfaceField writes val with a registered formatter or its String method
+// (see RegisterDumper) if either applies, or as JSON otherwise.
+func (e *logEvent) interfaceField(key string, val interface{}) {
+	if str, ok := e.service.formatValue(val); ok {
+		e.event.Str(key, str)
+	} else {
+		e.event.Interface(key, val)
+	}
+}
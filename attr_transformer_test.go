@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAttrTransformer(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.SetAttrTransformer(func(key string, val interface{}) (string, interface{}) {
+		switch key {
+		case "user":
+			return "user_id", val
+		case "password":
+			return "", nil
+		case "retries":
+			return key, "many"
+		}
+		return strings.ToLower(key), val
+	})
+
+	service.InfoWith().
+		Str("user", "u-42").
+		Str("password", "hunter2").
+		Int("retries", 3).
+		Bool("Cached", true).
+		Msg("login")
+
+	service.SetAttrTransformer(nil)
+	service.InfoWith().Str("user", "u-43").Msg("untransformed")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	first := entries[0]
+	assert.Equal(t, "u-42", first["user_id"])
+	assert.NotContains(t, first, "user")
+	assert.NotContains(t, first, "password")
+	assert.Equal(t, "many", first["retries"])
+	assert.Equal(t, true, first["cached"])
+	assert.Equal(t, "login", first["message"])
+
+	assert.Equal(t, "u-43", entries[1]["user"])
+}
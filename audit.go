@@ -0,0 +1,13 @@
+package logging
+
+// auditLevelName is the level field value written for audit events.
+const auditLevelName = "audit"
+
+// AuditWith returns a LogEvent for compliance audit records. Audit events are
+// written regardless of the configured level and sampling, carry
+// "level":"audit" and "audit":true, and participate in shutdown tracking like
+// any other event. A filter installed with SetFilter sees them as zerolog.NoLevel.
+// Example: svc.AuditWith().Str("user_id", id).Str("action", "delete").Msg("record deleted")
+func (s *Service) AuditWith() LogEvent {
+	return auditEventBuilder(s)
+}
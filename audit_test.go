@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditWith_BypassesLevel(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.Level = "panic"
+	service, dir := newFileTestService(t, cfg, Config{SampleBurst: 1, SamplePeriodMS: 60000})
+
+	service.InfoWith().Msg("suppressed info")
+	service.AuditWith().Str("user_id", "u1").Msg("record deleted")
+	service.AuditWith().Str("user_id", "u2").Msg("record restored")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "audit", entries[0]["level"])
+	assert.Equal(t, true, entries[0]["audit"])
+	assert.Equal(t, "u1", entries[0]["user_id"])
+	assert.Equal(t, "record deleted", entries[0]["message"])
+	assert.Equal(t, "u2", entries[1]["user_id"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestAuditWith_TracksLocation(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.ShutdownTimeoutWarning = true
+	service, _ := newFileTestService(t, cfg, Config{})
+
+	event := service.AuditWith()
+	service.mu.RLock()
+	var locations []string
+	for loc := range service.activeOpLocations {
+		locations = append(locations, loc)
+	}
+	service.mu.RUnlock()
+	event.Msg("done")
+
+	require.Len(t, locations, 1)
+	assert.Contains(t, locations[0], "audit_test.go")
+}
+
+func TestAuditWith_Uninitialized(t *testing.T) {
+	service := &Service{}
+	service.AuditWith().Msg("should not panic")
+}
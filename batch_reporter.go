@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"sync"
+
+	"go.uber.org/atomic"
+)
+
+// maxBatchErrorExamples is the number of error chains kept by a BatchReporter.
+const maxBatchErrorExamples = 5
+
+// BatchReporter counts the results of a batch and logs them as one summary line
+// instead of a line per record. Record and RecordErr are safe for concurrent use.
+// Example:
+//
+//	rep := svc.BatchReporter("import")
+//	for _, rec := range records { rep.RecordErr(store(rec)) }
+//	rep.Finish()
+type BatchReporter struct {
+	service  *Service
+	name     string
+	success  atomic.Int64
+	failure  atomic.Int64
+	finished atomic.Bool
+
+	mu       sync.Mutex
+	examples [][]string // Error chains of the first failures
+}
+
+// BatchReporter returns a new reporter for the batch called name.
+func (s *Service) BatchReporter(name string) *BatchReporter {
+	return &BatchReporter{service: s, name: name}
+}
+
+// Record counts one successful (ok) or failed record.
+func (b *BatchReporter) Record(ok bool) {
+	if ok {
+		b.success.Inc()
+		return
+	}
+	b.failure.Inc()
+}
+
+// RecordErr counts a failed record and keeps its error chain as an example, up
+// to maxBatchErrorExamples. A nil err counts as a success.
+func (b *BatchReporter) RecordErr(err error) {
+	if err == nil {
+		b.success.Inc()
+		return
+	}
+	b.failure.Inc()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.examples) < maxBatchErrorExamples {
+		chain, _, _, _ := buildErrorChain(err)
+		b.examples = append(b.examples, chain)
+	}
+}
+
+// Finish writes an Info line with batch, total, success and failure and, if any
+// record failed, an Error line with batch, failure and error_examples (the
+// chains of the first failed records). Only the first call logs; records added
+// afterwards are not reported.
+func (b *BatchReporter) Finish() {
+	if !b.finished.CompareAndSwap(false, true) {
+		return
+	}
+	success, failure := b.success.Load(), b.failure.Load()
+	b.service.InfoWith().
+		Str("batch", b.name).
+		Int64("total", success+failure).
+		Int64("success", success).
+		Int64("failure", failure).
+		Msg("batch finished")
+	if failure == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	examples := b.examples
+	b.mu.Unlock()
+	b.service.ErrorWith().
+		Str("batch", b.name).
+		Int64("failure", failure).
+		Interface("error_examples", examples).
+		Msg("batch failures")
+}
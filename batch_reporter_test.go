@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchReporter(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	rep := service.BatchReporter("import")
+
+	errInvalid := errors.New("invalid callsign")
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			switch {
+			case i%4 == 0:
+				rep.RecordErr(fmt.Errorf("record %d: %w", i, errInvalid))
+			case i%5 == 0:
+				rep.Record(false)
+			case i%2 == 0:
+				rep.RecordErr(nil)
+			default:
+				rep.Record(true)
+			}
+		}(i)
+	}
+	wg.Wait()
+	rep.Finish()
+	rep.Finish()
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+
+	summary := entries[0]
+	assert.Equal(t, "info", summary["level"])
+	assert.Equal(t, "import", summary["batch"])
+	assert.Equal(t, float64(20), summary["total"])
+	assert.Equal(t, float64(12), summary["success"])
+	assert.Equal(t, float64(8), summary["failure"])
+
+	failures := entries[1]
+	assert.Equal(t, "error", failures["level"])
+	assert.Equal(t, "import", failures["batch"])
+	assert.Equal(t, float64(8), failures["failure"])
+	examples, ok := failures["error_examples"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, examples, maxBatchErrorExamples)
+	for _, ex := range examples {
+		chain := ex.([]interface{})
+		require.Len(t, chain, 2)
+		assert.Equal(t, "invalid callsign", chain[1])
+	}
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestBatchReporter_NoFailures(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	rep := service.BatchReporter("sync")
+	rep.Record(true)
+	rep.Finish()
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, float64(1), entries[0]["total"])
+	assert.Equal(t, float64(0), entries[0]["failure"])
+}
@@ -6,13 +6,16 @@ import (
 	"testing"
 
 	smerrors "github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
 	"github.com/rs/zerolog"
 )
 
 // newBenchService constructs a Service with a discard logger at the given level.
 // It bypasses Initialize() to avoid I/O setup and focuses on pure logging overhead.
+// Event creation reads LoggingConfig, which Initialize would otherwise set, so
+// an empty one is provided.
 func newBenchService(level zerolog.Level) *Service {
-	s := &Service{}
+	s := &Service{LoggingConfig: &types.LoggingConfig{}}
 	logger := zerolog.New(io.Discard).Level(level)
 	s.logger.Store(&logger)
 	s.isInitialized.Store(true)
@@ -102,3 +105,32 @@ func BenchmarkParallel_ErrorWith_Detailed3(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkContextLogger_InfoWith(b *testing.B) {
+	s := newBenchService(zerolog.InfoLevel)
+	child := s.With().Str("component", "bench").Logger()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		child.InfoWith().Int("n", i).Msg("hello")
+	}
+}
+
+func BenchmarkContextLogger_DebugWith_Disabled(b *testing.B) {
+	s := newBenchService(zerolog.InfoLevel)
+	child := s.With().Str("component", "bench").Logger()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		child.DebugWith().Int("n", i).Msg("dropped")
+	}
+}
+
+func BenchmarkDebugWith_Disabled(b *testing.B) {
+	s := newBenchService(zerolog.InfoLevel)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.DebugWith().Str("k", "v").Msg("dropped")
+	}
+}
@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// maxBreadcrumbs is the number of breadcrumbs a service keeps; older ones are
+// overwritten.
+const maxBreadcrumbs = 20
+
+// breadcrumbsFieldName holds the trail attached by Err when
+// Config.AttachBreadcrumbs is set.
+const breadcrumbsFieldName = "breadcrumbs"
+
+// breadcrumb is one entry of the trail, in the form it is written.
+type breadcrumb struct {
+	Time    time.Time              `json:"time"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// breadcrumbRing is a fixed-size ring of the most recent breadcrumbs.
+type breadcrumbRing struct {
+	mu    sync.Mutex
+	items [maxBreadcrumbs]breadcrumb
+	next  int // Index the next breadcrumb is written to
+	count int
+}
+
+func (r *breadcrumbRing) add(b breadcrumb) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items[r.next] = b
+	r.next = (r.next + 1) % maxBreadcrumbs
+	if r.count < maxBreadcrumbs {
+		r.count++
+	}
+}
+
+// snapshot returns the breadcrumbs from oldest to newest, or nil if there are none.
+func (r *breadcrumbRing) snapshot() []breadcrumb {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.count == 0 {
+		return nil
+	}
+	out := make([]breadcrumb, 0, r.count)
+	start := (r.next - r.count + maxBreadcrumbs) % maxBreadcrumbs
+	for i := 0; i < r.count; i++ {
+		out = append(out, r.items[(start+i)%maxBreadcrumbs])
+	}
+	return out
+}
+
+// Breadcrumb records msg and fields in a rolling trail of the last 20 events
+// without writing a line. When Config.AttachBreadcrumbs is set, the trail is
+// attached as a breadcrumbs array to every event given an error through Err,
+// to show what led up to it. fields is copied; it may be nil. Without
+// AttachBreadcrumbs nothing is recorded.
+// Example: svc.Breadcrumb("cat connected", map[string]interface{}{"port": "/dev/ttyUSB0"})
+func (s *Service) Breadcrumb(msg string, fields map[string]interface{}) {
+	if s == nil || !s.Config.AttachBreadcrumbs {
+		return
+	}
+	var data map[string]interface{}
+	if len(fields) > 0 {
+		data = make(map[string]interface{}, len(fields))
+		for k, v := range fields {
+			data[k] = v
+		}
+	}
+	s.breadcrumbs.add(breadcrumb{Time: s.now(), Message: msg, Data: data})
+}
+
+// attachBreadcrumbs adds the breadcrumb trail to e if Config.AttachBreadcrumbs is set.
+func (e *logEvent) attachBreadcrumbs() {
+	if e.service == nil || !e.service.Config.AttachBreadcrumbs {
+		return
+	}
+	if trail := e.service.breadcrumbs.snapshot(); trail != nil {
+		e.event.Interface(breadcrumbsFieldName, trail)
+	}
+}
@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreadcrumbs_AttachedOnError(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{AttachBreadcrumbs: true})
+
+	fields := map[string]interface{}{"port": "/dev/ttyUSB0"}
+	service.Breadcrumb("cat connected", fields)
+	fields["port"] = "mutated"
+	service.Breadcrumb("frequency changed", map[string]interface{}{"khz": 14074})
+	service.Breadcrumb("poll", nil)
+
+	service.ErrorWith().Err(errors.New("rig timeout")).Msg("poll failed")
+	service.InfoWith().Msg("no error, no trail")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+
+	trail, ok := entries[0]["breadcrumbs"].([]interface{})
+	require.True(t, ok, "breadcrumbs is an array, got %T", entries[0]["breadcrumbs"])
+	require.Len(t, trail, 3)
+
+	first := trail[0].(map[string]interface{})
+	assert.Equal(t, "cat connected", first["message"])
+	assert.Equal(t, map[string]interface{}{"port": "/dev/ttyUSB0"}, first["data"])
+	assert.NotEmpty(t, first["time"])
+	assert.Equal(t, map[string]interface{}{"khz": float64(14074)}, trail[1].(map[string]interface{})["data"])
+	assert.Equal(t, "poll", trail[2].(map[string]interface{})["message"])
+	assert.NotContains(t, trail[2], "data")
+
+	assert.NotContains(t, entries[1], "breadcrumbs")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestBreadcrumbs_KeepsMostRecent(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{AttachBreadcrumbs: true})
+
+	for i := 0; i < maxBreadcrumbs+5; i++ {
+		service.Breadcrumb(fmt.Sprintf("step %d", i), nil)
+	}
+	service.ErrorWith().Err(errors.New("boom")).Msg("failed")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	trail := entries[0]["breadcrumbs"].([]interface{})
+	require.Len(t, trail, maxBreadcrumbs)
+	assert.Equal(t, "step 5", trail[0].(map[string]interface{})["message"])
+	assert.Equal(t, fmt.Sprintf("step %d", maxBreadcrumbs+4), trail[maxBreadcrumbs-1].(map[string]interface{})["message"])
+}
+
+func TestBreadcrumbs_Disabled(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.Breadcrumb("ignored", nil)
+	service.ErrorWith().Err(errors.New("boom")).Msg("failed")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.NotContains(t, entries[0], "breadcrumbs")
+	assert.Nil(t, service.breadcrumbs.snapshot())
+
+	var nilService *Service
+	assert.NotPanics(t, func() { nilService.Breadcrumb("x", nil) })
+}
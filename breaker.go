@@ -0,0 +1,191 @@
+package logging
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Defaults for Config.BreakerThreshold and Config.BreakerWindowMS.
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerWindow    = 10 * time.Second
+)
+
+// LogBreaker suppresses a flood of errors from a failing dependency. While
+// closed, every error is logged. When BreakerThreshold errors arrive within
+// BreakerWindowMS the breaker opens: a Warn line is written and further errors
+// with the same text as those in the window are only counted. Other errors are
+// still logged, and open the breaker for their own text in the same way. Once
+// no suppressed error arrived for a whole window the breaker closes again and
+// writes an Info summary with the number of suppressed errors, per error text
+// and in total. Open breakers are also closed by Service.Close. Error is safe
+// for concurrent use.
+// Example:
+//
+//	if err := radio.Poll(); err != nil { svc.Breaker("radio").Error(err) }
+type LogBreaker struct {
+	service   *Service
+	key       string
+	threshold int
+	window    time.Duration
+
+	mu          sync.Mutex
+	open        bool
+	windowStart time.Time           // Start of the window errors are counted in
+	count       int                 // Errors in the current window that were logged
+	seen        map[string]struct{} // Texts of the errors counted in the current window
+	openedAt    time.Time           // When the breaker opened
+	suppressed  map[string]int64    // Error text -> errors counted but not logged while open
+	lastErr     error               // Last suppressed error
+	closeTimer  *time.Timer         // Closes the breaker after a quiet window
+	openings    int                 // Incremented on every opening, so a stale timer cannot close a later one
+}
+
+// Breaker returns the log breaker for key, creating it on first use; the same
+// key always returns the same breaker.
+func (s *Service) Breaker(key string) *LogBreaker {
+	if s == nil {
+		return &LogBreaker{key: key, threshold: defaultBreakerThreshold, window: defaultBreakerWindow}
+	}
+	if b, ok := s.breakers.Load(key); ok {
+		return b.(*LogBreaker)
+	}
+	threshold := s.Config.BreakerThreshold
+	if threshold == 0 {
+		threshold = defaultBreakerThreshold
+	}
+	window := time.Duration(s.Config.BreakerWindowMS) * time.Millisecond
+	if window == 0 {
+		window = defaultBreakerWindow
+	}
+	b, _ := s.breakers.LoadOrStore(key, &LogBreaker{service: s, key: key, threshold: threshold, window: window})
+	return b.(*LogBreaker)
+}
+
+// Error logs err at Error level with the breaker field, or counts it if the
+// breaker is open for its text. A nil err is ignored.
+func (b *LogBreaker) Error(err error) {
+	if err == nil {
+		return
+	}
+	now := time.Now()
+	text := err.Error()
+
+	b.mu.Lock()
+	if n, ok := b.suppressed[text]; ok && b.open {
+		b.suppressed[text] = n + 1
+		b.lastErr = err
+		b.closeTimer.Reset(b.window)
+		b.mu.Unlock()
+		return
+	}
+	if now.Sub(b.windowStart) > b.window {
+		b.windowStart = now
+		b.count = 0
+		clear(b.seen)
+	}
+	b.count++
+	if b.seen == nil {
+		b.seen = make(map[string]struct{})
+	}
+	b.seen[text] = struct{}{}
+	tripping := b.count >= b.threshold
+	if tripping {
+		if !b.open {
+			b.open = true
+			b.openedAt = now
+			b.suppressed = make(map[string]int64, len(b.seen))
+			b.lastErr = nil
+			b.openings++
+			n := b.openings
+			b.closeTimer = time.AfterFunc(b.window, func() { b.close(n) })
+		}
+		// Every error of the flooding window is suppressed from now on
+		for seen := range b.seen {
+			if _, ok := b.suppressed[seen]; !ok {
+				b.suppressed[seen] = 0
+			}
+		}
+		b.windowStart = now
+		b.count = 0
+		clear(b.seen)
+	}
+	b.mu.Unlock()
+
+	b.service.ErrorWith().Str("breaker", b.key).Err(err).Msg("dependency error")
+	if tripping {
+		b.service.WarnWith().
+			Str("breaker", b.key).
+			Int("errors", b.threshold).
+			Dur("window_ms", b.window).
+			Msg("breaker open, suppressing errors")
+	}
+}
+
+// IsOpen reports whether errors are currently being suppressed.
+func (b *LogBreaker) IsOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.open
+}
+
+// close closes the breaker after a quiet window and writes the summary, unless
+// it was already closed since the given opening.
+func (b *LogBreaker) close(opening int) {
+	b.mu.Lock()
+	if !b.open || b.openings != opening {
+		b.mu.Unlock()
+		return
+	}
+	b.open = false
+	b.closeTimer.Stop()
+	b.count = 0
+	b.windowStart = time.Time{}
+	clear(b.seen)
+	counts, lastErr, openFor := b.suppressed, b.lastErr, time.Since(b.openedAt)
+	b.suppressed, b.lastErr = nil, nil
+	b.mu.Unlock()
+
+	var total int64
+	perError := make(map[string]int64, len(counts))
+	for text, n := range counts {
+		if n > 0 {
+			perError[text] = n
+			total += n
+		}
+	}
+	event := b.service.InfoWith().
+		Str("breaker", b.key).
+		Int64("suppressed", total).
+		Dur("open_ms", openFor)
+	if len(perError) > 0 {
+		event = event.Interface("suppressed_errors", perError)
+	}
+	if lastErr != nil {
+		event = event.Err(lastErr)
+	}
+	event.Msg("breaker closed")
+}
+
+// flush closes the breaker now if it is open, writing its summary.
+func (b *LogBreaker) flush() {
+	b.mu.Lock()
+	opening := b.openings
+	b.mu.Unlock()
+	b.close(opening)
+}
+
+// closeBreakers closes every open breaker of the service, writing their
+// summaries while the logger is still available.
+func (s *Service) closeBreakers() {
+	var breakers []*LogBreaker
+	s.breakers.Range(func(_, b any) bool {
+		breakers = append(breakers, b.(*LogBreaker))
+		return true
+	})
+	sort.Slice(breakers, func(i, j int) bool { return breakers[i].key < breakers[j].key })
+	for _, b := range breakers {
+		b.flush()
+	}
+}
@@ -0,0 +1,117 @@
+package logging
+
+import (
+	stderrs "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogBreaker(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{BreakerThreshold: 3, BreakerWindowMS: 200})
+
+	breaker := service.Breaker("radio")
+	assert.Same(t, breaker, service.Breaker("radio"))
+	assert.NotSame(t, breaker, service.Breaker("db"))
+
+	refused := stderrs.New("connection refused")
+	for i := 0; i < 50; i++ {
+		breaker.Error(refused)
+	}
+	breaker.Error(nil)
+	assert.True(t, breaker.IsOpen())
+
+	// Quiet time closes the breaker and writes the summary
+	require.Eventually(t, func() bool { return !breaker.IsOpen() }, 5*time.Second, 10*time.Millisecond)
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 5)
+	for _, entry := range entries[:3] {
+		assert.Equal(t, "error", entry["level"])
+		assert.Equal(t, "radio", entry["breaker"])
+		assert.Equal(t, "connection refused", entry["error"])
+	}
+	assert.Equal(t, "warn", entries[3]["level"])
+	assert.Equal(t, "breaker open, suppressing errors", entries[3]["message"])
+	assert.Equal(t, float64(3), entries[3]["errors"])
+
+	summary := entries[4]
+	assert.Equal(t, "info", summary["level"])
+	assert.Equal(t, "breaker closed", summary["message"])
+	assert.Equal(t, "radio", summary["breaker"])
+	assert.Equal(t, float64(47), summary["suppressed"])
+	assert.Equal(t, "connection refused", summary["error"])
+	assert.GreaterOrEqual(t, summary["open_ms"].(float64), float64(200))
+
+	// Closed again: errors are logged
+	breaker.Error(refused)
+	entries = readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 6)
+	assert.Equal(t, "dependency error", entries[5]["message"])
+}
+
+func TestLogBreaker_SpreadOutErrorsStayClosed(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{BreakerThreshold: 2, BreakerWindowMS: 20})
+
+	breaker := service.Breaker("db")
+	for i := 0; i < 3; i++ {
+		breaker.Error(stderrs.New("timeout"))
+		time.Sleep(40 * time.Millisecond)
+	}
+	assert.False(t, breaker.IsOpen())
+	assert.Len(t, readLogEntries(t, dir, logFileName(service)), 3)
+
+	var nilService *Service
+	nilService.Breaker("x").Error(stderrs.New("ignored"))
+}
+
+func TestLogBreaker_OnlySuppressesFloodingErrors(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{BreakerThreshold: 3, BreakerWindowMS: 60000})
+	name := logFileName(service)
+
+	breaker := service.Breaker("radio")
+	for i := 0; i < 10; i++ {
+		breaker.Error(stderrs.New("connection refused"))
+	}
+	require.True(t, breaker.IsOpen())
+
+	// A different error is still logged while the breaker is open
+	breaker.Error(stderrs.New("bad checksum"))
+
+	// Close writes the summary of a breaker that is still open
+	require.NoError(t, service.Close())
+	assert.False(t, breaker.IsOpen())
+
+	entries := readLogEntries(t, dir, name)
+	require.Len(t, entries, 6)
+	assert.Equal(t, "breaker open, suppressing errors", entries[3]["message"])
+	assert.Equal(t, "bad checksum", entries[4]["error"])
+
+	summary := entries[5]
+	assert.Equal(t, "breaker closed", summary["message"])
+	assert.Equal(t, float64(7), summary["suppressed"])
+	assert.Equal(t, map[string]any{"connection refused": float64(7)}, summary["suppressed_errors"])
+}
+
+func TestLogBreaker_CountsEachSuppressedError(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{BreakerThreshold: 2, BreakerWindowMS: 60000})
+	name := logFileName(service)
+
+	breaker := service.Breaker("db")
+	breaker.Error(stderrs.New("timeout"))
+	breaker.Error(stderrs.New("deadlock"))
+	for i := 0; i < 3; i++ {
+		breaker.Error(stderrs.New("timeout"))
+	}
+	breaker.Error(stderrs.New("deadlock"))
+	require.NoError(t, service.Close())
+
+	entries := readLogEntries(t, dir, name)
+	require.Len(t, entries, 4)
+	summary := entries[3]
+	assert.Equal(t, float64(4), summary["suppressed"])
+	assert.Equal(t, map[string]any{"timeout": float64(3), "deadlock": float64(1)}, summary["suppressed_errors"])
+	assert.Equal(t, "deadlock", summary["error"])
+}
@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"bufio"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+)
+
+// bufferedWriterSize is the buffer size used when periodic flushing is enabled.
+const bufferedWriterSize = 64 * 1024
+
+// bufferedWriter batches writes to an underlying writer and flushes them on a
+// fixed interval from a background goroutine.
+//
+// Crash-safety: lines written since the last flush live only in memory and are
+// lost if the process crashes or is killed. Fatal and panic level lines flush
+// the buffer immediately so they are not lost when zerolog exits or panics.
+// Close performs a final flush.
+//
+// A failed write to the underlying writer drops the buffered bytes (counted in
+// dropped) and the writer carries on; bufio.Writer would otherwise fail every
+// later call with the same error.
+type bufferedWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	buf     *bufio.Writer
+	dropped *atomic.Int64
+	stop    chan struct{}
+	done    chan struct{}
+	closed  bool
+}
+
+// newBufferedWriter wraps w in a buffered writer and starts the flush ticker.
+// Bytes lost to write errors are added to dropped.
+func newBufferedWriter(w io.Writer, interval time.Duration, dropped *atomic.Int64) *bufferedWriter {
+	bw := &bufferedWriter{
+		w:       w,
+		buf:     bufio.NewWriterSize(w, bufferedWriterSize),
+		dropped: dropped,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go bw.flushLoop(interval)
+	return bw
+}
+
+// flushLoop flushes the buffer on each tick until stopped.
+func (bw *bufferedWriter) flushLoop(interval time.Duration) {
+	defer close(bw.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = bw.Flush()
+		case <-bw.stop:
+			return
+		}
+	}
+}
+
+// Write implements io.Writer.
+func (bw *bufferedWriter) Write(p []byte) (int, error) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	return bw.write(p)
+}
+
+// WriteLevel implements zerolog.LevelWriter, flushing immediately for lines that
+// precede a process exit or panic.
+func (bw *bufferedWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	n, err := bw.write(p)
+	if err == nil && (level == zerolog.FatalLevel || level == zerolog.PanicLevel) {
+		err = bw.flush()
+	}
+	return n, err
+}
+
+// write buffers p. The caller must hold bw.mu.
+func (bw *bufferedWriter) write(p []byte) (int, error) {
+	n, err := bw.buf.Write(p)
+	if err != nil {
+		// The part of p that was not taken is lost along with the buffer
+		bw.dropped.Add(int64(len(p) - n))
+		bw.reset()
+	}
+	return n, err
+}
+
+// flush writes the buffer out. The caller must hold bw.mu.
+func (bw *bufferedWriter) flush() error {
+	err := bw.buf.Flush()
+	if err != nil {
+		bw.reset()
+	}
+	return err
+}
+
+// reset drops whatever is still buffered after a write error and clears the
+// error, so the next write starts afresh. The caller must hold bw.mu.
+func (bw *bufferedWriter) reset() {
+	bw.dropped.Add(int64(bw.buf.Buffered()))
+	bw.buf.Reset(bw.w)
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (bw *bufferedWriter) Flush() error {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	return bw.flush()
+}
+
+// Close stops the flush ticker and performs a final flush. It does not close the
+// underlying writer. It is safe to call multiple times.
+func (bw *bufferedWriter) Close() error {
+	bw.mu.Lock()
+	if bw.closed {
+		bw.mu.Unlock()
+		return nil
+	}
+	bw.closed = true
+	bw.mu.Unlock()
+
+	close(bw.stop)
+	<-bw.done
+	return bw.Flush()
+}
+
+// DroppedBufferedBytes returns the number of bytes lost because the log file
+// failed to accept a flush of the Config.FlushIntervalMS buffer. Writing
+// resumes with the next line after such a failure.
+func (s *Service) DroppedBufferedBytes() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.bufferedDrops.Load()
+}
@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestBufferedWriter_FlushesOnInterval(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{FlushIntervalMS: 20})
+	require.NotNil(t, service.bufWriter)
+
+	service.InfoWith().Msg("flushed by ticker")
+
+	path := filepath.Join(dir, logFileName(service))
+	assert.Eventually(t, func() bool {
+		data, err := os.ReadFile(path)
+		return err == nil && len(data) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "flushed by ticker", entries[0]["message"])
+}
+
+func TestBufferedWriter_CloseFlushesRemainder(t *testing.T) {
+	// Long interval so only Close can flush
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{FlushIntervalMS: 60000})
+	name := logFileName(service)
+
+	service.InfoWith().Msg("pending line")
+
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err == nil {
+		assert.Empty(t, data, "line should still be buffered")
+	}
+
+	require.NoError(t, service.Close())
+
+	entries := readLogEntries(t, dir, name)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "pending line", entries[0]["message"])
+}
+
+func TestBufferedWriter_NegativeIntervalRejected(t *testing.T) {
+	service := &Service{
+		WorkingDir:    t.TempDir(),
+		ConfigService: newTestConfigService(validLoggingConfig()),
+		Config:        Config{FlushIntervalMS: -1},
+	}
+	err := service.Initialize()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "FlushIntervalMS")
+}
+
+// flakyWriter fails while failing is set and records what it accepts otherwise.
+type flakyWriter struct {
+	failing bool
+	data    []byte
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	if w.failing {
+		return 0, errDiskFull
+	}
+	w.data = append(w.data, p...)
+	return len(p), nil
+}
+
+func TestBufferedWriter_RecoversAfterWriteError(t *testing.T) {
+	var dropped atomic.Int64
+	w := &flakyWriter{failing: true}
+	bw := newBufferedWriter(w, time.Hour, &dropped)
+	defer func() { _ = bw.Close() }()
+
+	_, err := bw.Write([]byte("lost line\n"))
+	require.NoError(t, err)
+	require.ErrorIs(t, bw.Flush(), errDiskFull)
+	assert.Equal(t, int64(len("lost line\n")), dropped.Load())
+
+	// bufio.Writer would keep returning the first error; the reset clears it
+	w.failing = false
+	_, err = bw.Write([]byte("next line\n"))
+	require.NoError(t, err)
+	require.NoError(t, bw.Flush())
+	assert.Equal(t, "next line\n", string(w.data))
+	assert.Equal(t, int64(len("lost line\n")), dropped.Load())
+
+	// A write larger than the buffer goes straight through and fails on its own
+	w.failing = true
+	big := make([]byte, bufferedWriterSize+1)
+	n, err := bw.Write(big)
+	require.ErrorIs(t, err, errDiskFull)
+	assert.Equal(t, int64(len("lost line\n")+len(big)-n), dropped.Load())
+	w.failing = false
+	require.NoError(t, bw.Flush())
+}
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallerTrim(t *testing.T) {
+	_, thisFile, _, ok := runtime.Caller(0)
+	require.True(t, ok)
+	dir := filepath.Dir(thisFile)
+
+	tests := []struct {
+		name  string
+		local Config
+		want  string
+	}{
+		{name: "untrimmed", local: Config{}, want: thisFile},
+		{name: "prefix", local: Config{CallerTrimPrefix: filepath.Dir(dir) + "/"}, want: filepath.Base(dir) + "/caller_test.go"},
+		{name: "auto", local: Config{CallerTrimAuto: true}, want: filepath.Base(dir) + "/caller_test.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validLoggingConfig()
+			cfg.SkipFrameCount = 3
+			service, logDir := newFileTestService(t, cfg, tt.local)
+
+			_, _, line, _ := runtime.Caller(0)
+			service.InfoWith().Msg("where am I") // must stay on the line after runtime.Caller
+
+			entries := readLogEntries(t, logDir, logFileName(service))
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.want+":"+strconv.Itoa(line+1), entries[0]["caller"])
+		})
+	}
+}
+
+func TestCallerHookTrim(t *testing.T) {
+	assert.Equal(t, "pkg/file.go", callerHook{trimPrefix: "/ci/src/app/"}.trim("/ci/src/app/pkg/file.go"))
+	assert.Equal(t, "/other/file.go", callerHook{trimPrefix: "/ci/"}.trim("/other/file.go"))
+	assert.Equal(t, "pkg/file.go", callerHook{auto: true}.trim("/ci/src/app/pkg/file.go"))
+	assert.Equal(t, "file.go", callerHook{auto: true}.trim("file.go"))
+}
@@ -0,0 +1,41 @@
+package logging
+
+import "time"
+
+// SetClock sets the function the timestamp field is read from, e.g. a fixed
+// time for tests that assert on timestamps. It applies immediately to the
+// service and its context loggers, and only has an effect when WithTimestamp
+// is enabled. Passing nil restores the default, zerolog.TimestampFunc.
+func (s *Service) SetClock(fn func() time.Time) {
+	if s == nil {
+		return
+	}
+	if fn == nil {
+		s.clock.Store(nil)
+		return
+	}
+	s.clock.Store(&fn)
+}
+
+// now returns the current time from the service clock, or time.Now on a nil
+// service.
+func (s *Service) now() time.Time {
+	if s == nil {
+		return time.Now()
+	}
+	if fn := s.clock.Load(); fn != nil {
+		return (*fn)()
+	}
+	return time.Now()
+}
+
+// clockFunc returns the clock set on the service, or nil for the default.
+func (h timestampHook) clockFunc() func() time.Time {
+	if h.clock == nil {
+		return nil
+	}
+	if fn := h.clock.Load(); fn != nil {
+		return *fn
+	}
+	return nil
+}
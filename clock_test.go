@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/logging/logtest"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SetClock(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.WithTimestamp = true
+	service, dir := newFileTestService(t, cfg, Config{})
+	child := service.With().Str("k", "v").Logger()
+
+	fixed := time.Date(2025, 6, 15, 8, 0, 0, 0, time.UTC)
+	service.SetClock(func() time.Time { return fixed })
+
+	service.InfoWith().Msg("one")
+	child.WarnWith().Msg("two")
+	service.Event().Str("a", "b").Error().Msg("three")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 3)
+	for _, entry := range entries {
+		assert.Equal(t, "2025-06-15T08:00:00Z", entry["time"])
+	}
+
+	service.SetClock(nil)
+	service.InfoWith().Msg("real time")
+	entries = readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 4)
+	assert.NotEqual(t, "2025-06-15T08:00:00Z", entries[3]["time"])
+}
+
+func TestService_SetClockWithFormat(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.WithTimestamp = true
+
+	var buf threadSafeBuffer
+	service := &Service{
+		WorkingDir:    t.TempDir(),
+		ConfigService: newTestConfigService(cfg),
+		Config:        Config{TimestampFormat: "2006-01-02 15:04:05.000", TimestampUTC: true},
+	}
+	require.NoError(t, service.Initialize(WithWriter(&buf)))
+	defer func() { _ = service.Close() }()
+
+	service.SetClock(func() time.Time {
+		return time.Date(2025, 6, 15, 10, 0, 0, 123e6, time.FixedZone("CEST", 2*60*60))
+	})
+	service.InfoWith().Msg("formatted")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+	logtest.AssertField(t, []byte(lines[0]), "time", "2025-06-15 08:00:00.123")
+}
+
+func TestService_SetClockNilService(t *testing.T) {
+	var service *Service
+	assert.NotPanics(t, func() { service.SetClock(time.Now) })
+}
+
+func TestService_DefaultClockUsesZerologTimestampFunc(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.WithTimestamp = true
+	service, dir := newFileTestService(t, cfg, Config{})
+
+	previous := zerolog.TimestampFunc
+	t.Cleanup(func() { zerolog.TimestampFunc = previous })
+	zerolog.TimestampFunc = func() time.Time { return time.Date(2025, 6, 15, 8, 0, 0, 0, time.UTC) }
+
+	service.InfoWith().Msg("global")
+	service.SetClock(func() time.Time { return time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC) })
+	service.InfoWith().Msg("service clock")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "2025-06-15T08:00:00Z", entries[0]["time"])
+	assert.Equal(t, "2030-01-01T00:00:00Z", entries[1]["time"])
+}
@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"path/filepath"
+
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+)
+
+// Clone creates and initializes a new, independent Service that starts from a
+// copy of this service's LoggingConfig and Config (and the clock set with
+// WithClock or SetClock, if any). The overrides function, if not nil, may
+// modify the copied LoggingConfig (for example the Level or RelLogFileDir)
+// before the clone is initialized. The clone opens its own file writer and has
+// its own lifecycle: it must be closed separately, and closing either service
+// does not affect the other. Since both would rotate the same file, Clone fails
+// when both log to a file and the overrides leave RelLogFileDir unchanged. The
+// receiver must be initialized.
+func (s *Service) Clone(overrides func(*types.LoggingConfig)) (*Service, error) {
+	const op errors.Op = "logging.Service.Clone"
+	if s == nil {
+		return nil, errors.New(op).Msg(errMsgNilService)
+	}
+
+	s.mu.RLock()
+	if s.LoggingConfig == nil {
+		s.mu.RUnlock()
+		return nil, errors.New(op).Msg(errMsgNilConfig)
+	}
+	loggingCfg := *s.LoggingConfig
+	parentFile := s.fileWriter != nil
+	parentDir := s.LoggingConfig.RelLogFileDir
+	var opts initOptions
+	if clock := s.clock.Load(); clock != nil {
+		opts.clock = *clock
+	}
+	clone := &Service{
+		WorkingDir:    s.WorkingDir,
+		ConfigService: s.ConfigService,
+		Config:        s.Config.clone(),
+	}
+	s.mu.RUnlock()
+
+	if overrides != nil {
+		overrides(&loggingCfg)
+	}
+	if parentFile && fileLoggingEnabled(&loggingCfg) &&
+		filepath.Clean(loggingCfg.RelLogFileDir) == filepath.Clean(parentDir) {
+		return nil, errors.New(op).Msg("Clone must log to a different RelLogFileDir than the original.")
+	}
+
+	clone.initOnce.Do(func() {
+		clone.initErr = clone.initialize(loggingCfg, opts)
+	})
+	if clone.initErr != nil {
+		return nil, errors.New(op).Err(clone.initErr).Msg("failed to initialize clone")
+	}
+	return clone, nil
+}
@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Clone(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	clone, err := service.Clone(func(cfg *types.LoggingConfig) {
+		cfg.Level = "warn"
+		cfg.RelLogFileDir = "clone"
+	})
+	require.NoError(t, err)
+	require.NotNil(t, clone)
+	cloneDir := filepath.Join(service.WorkingDir, "clone")
+
+	// Original config is untouched
+	assert.Equal(t, "debug", service.LoggingConfig.Level)
+	assert.Equal(t, "warn", clone.LoggingConfig.Level)
+	assert.NotSame(t, service.fileWriter, clone.fileWriter)
+
+	service.InfoWith().Msg("original info")
+	clone.InfoWith().Msg("clone info")
+	clone.WarnWith().Msg("clone warn")
+
+	// Closing the clone leaves the original usable
+	require.NoError(t, clone.Close())
+	service.InfoWith().Msg("original after clone close")
+
+	original := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, original, 2)
+	assert.Equal(t, "original info", original[0]["message"])
+	assert.Equal(t, "original after clone close", original[1]["message"])
+
+	files, err := os.ReadDir(cloneDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	cloned := readLogEntries(t, cloneDir, files[0].Name())
+	require.Len(t, cloned, 1)
+	assert.Equal(t, "clone warn", cloned[0]["message"])
+}
+
+func TestService_CloneErrors(t *testing.T) {
+	t.Run("uninitialized", func(t *testing.T) {
+		_, err := (&Service{}).Clone(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("same log file", func(t *testing.T) {
+		service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+		_, err := service.Clone(func(cfg *types.LoggingConfig) { cfg.Level = "warn" })
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "RelLogFileDir")
+
+		// A console-only clone opens no file
+		clone, err := service.Clone(func(cfg *types.LoggingConfig) {
+			cfg.FileLogging = false
+			cfg.ConsoleLogging = true
+		})
+		require.NoError(t, err)
+		assert.Nil(t, clone.fileWriter)
+		require.NoError(t, clone.Close())
+	})
+
+	t.Run("invalid override", func(t *testing.T) {
+		service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+		_, err := service.Clone(func(cfg *types.LoggingConfig) { cfg.Level = "loud" })
+		require.Error(t, err)
+		assert.True(t, service.isInitialized.Load())
+	})
+}
@@ -0,0 +1,254 @@
+package logging
+
+// Error enrichment modes for Config.ErrorEnrichment.
+const (
+	// ErrorEnrichmentFull emits the complete chain fields (chain, root, history, ops, root op).
+	ErrorEnrichmentFull = "full"
+	// ErrorEnrichmentRootOnly emits only the root cause and root operation.
+	ErrorEnrichmentRootOnly = "root-only"
+	// ErrorEnrichmentOff emits only the bare zerolog error field.
+	ErrorEnrichmentOff = "off"
+)
+
+// Sampling modes for Config.SampleMode.
+const (
+	// SampleModeBurst samples every event with the burst sampler (the default).
+	SampleModeBurst = "burst"
+	// SampleModeFirstPerMessage always writes the first occurrence of each
+	// distinct error-level message and samples the repeats.
+	SampleModeFirstPerMessage = "first-per-message"
+)
+
+// Config holds logging settings that are specific to this package and complement
+// types.LoggingConfig. The zero value preserves the default behaviour, so it only
+// needs to be populated when one of the optional features is wanted. It must be
+// set before Initialize is called.
+type Config struct {
+	// ErrorEnrichment controls which error chain fields Err/AnErr emit:
+	// "full" (default when empty), "root-only" or "off".
+	ErrorEnrichment string
+
+	// FlushIntervalMS, when > 0, buffers file writes in memory and flushes them on
+	// this interval (and on Close). Lines not yet flushed are lost on a crash.
+	FlushIntervalMS int
+
+	// FileConsoleFormat writes the log file in the human-readable console format
+	// (without color) instead of JSON.
+	FileConsoleFormat bool
+
+	// TimestampUTC normalizes timestamps to UTC. Requires WithTimestamp.
+	TimestampUTC bool
+
+	// TimestampFormat is the time layout for the timestamp field (e.g.
+	// "2006-01-02T15:04:05.000Z07:00" for millisecond precision), or one of
+	// zerolog's UNIXMS/UNIXMICRO/UNIXNANO formats. Empty uses zerolog.TimeFieldFormat.
+	// It applies to this service only; zerolog's globals are left untouched.
+	TimestampFormat string
+
+	// SampleBurst and SamplePeriodMS enable burst sampling: at most SampleBurst
+	// events are written per SamplePeriodMS window, the rest are dropped. Both
+	// must be set together.
+	SampleBurst    int
+	SamplePeriodMS int
+
+	// SampleMode selects how sampling treats errors: "burst" (default when empty)
+	// samples all events alike; "first-per-message" always writes the first
+	// occurrence of each distinct error-level message (message plus error text)
+	// and samples only the repeats. Requires SampleBurst/SamplePeriodMS.
+	SampleMode string
+
+	// ErrorFileEnabled additionally writes error, fatal and panic events to
+	// errors.log under RelLogFileDir, using the same rotation settings.
+	ErrorFileEnabled bool
+
+	// DedupeWindowMS, when > 0, suppresses lines identical to any line (same
+	// level, message and fields; the timestamp is ignored) written within this
+	// window, so alternating repeats are suppressed as well. The number of suppressed lines is added as a
+	// duplicate_suppressed field to the next line that is written; on Close the
+	// last suppressed line is written with the count of those before it.
+	DedupeWindowMS int
+
+	// ErrorOpsAllowPrefix, when not empty, limits the operation identifiers
+	// emitted in error_ops and error_root_op to those starting with one of these
+	// prefixes. Dropped ops are replaced by empty strings in error_ops so that it
+	// stays positionally aligned with error_chain.
+	ErrorOpsAllowPrefix []string
+
+	// ComponentKey is the field name used by WithComponent. Empty uses "component".
+	ComponentKey string
+
+	// FallbackToStderr copies lines that the log file writers fail to write (for
+	// example on a full disk) to stderr, preceded by a one-time notice. Nil means
+	// enabled; set it to a pointer to false to disable. It has no effect when
+	// ConsoleLogging is on, since every line already reaches stderr.
+	FallbackToStderr *bool
+
+	// CallerTrimPrefix is removed from the start of the caller file path (e.g. the
+	// CI checkout directory), so that "/build/src/app/pkg/file.go:12" becomes
+	// "pkg/file.go:12". Only applies when SkipFrameCount enables caller info.
+	CallerTrimPrefix string
+
+	// CallerTrimAuto shortens the caller path to its last directory and file name
+	// when CallerTrimPrefix is empty. Only applies when SkipFrameCount enables caller info.
+	CallerTrimAuto bool
+
+	// ErrorIncludeType adds error_type (the Go type of the logged error, as %T)
+	// and, for wrapped errors, error_root_type for the innermost error. AnErr uses
+	// its key as the prefix.
+	ErrorIncludeType bool
+
+	// ErrorHistorySeparator separates the links of error_history. Empty uses " -> ".
+	ErrorHistorySeparator string
+
+	// ErrorHistoryMaxLinks, when > 0, keeps only the outermost links in
+	// error_history, followed by " (+N more)". error_chain is not shortened.
+	ErrorHistoryMaxLinks int
+
+	// StableFieldOrder rewrites every JSON line so that the FieldOrder keys come
+	// first (by default the timestamp, level and message fields), followed by the
+	// other keys in their original order. It costs a JSON parse per line and does
+	// not affect console-formatted output.
+	StableFieldOrder bool
+
+	// FieldOrder is the priority key list used by StableFieldOrder.
+	FieldOrder []string
+
+	// ConsoleMinLevel, when set, drops console events below this level while the
+	// log file keeps everything allowed by LoggingConfig.Level (e.g. "info" on the
+	// console with "debug" in the file). Audit events are always shown.
+	ConsoleMinLevel string
+
+	// FatalExitCode is the process exit code used after a FatalWith event is
+	// written. Zero uses 1.
+	FatalExitCode int
+
+	// DisableExitOnFatal writes fatal and panic lines (still marked fatal and
+	// panic) without exiting or panicking afterwards, so tests can exercise
+	// code paths that log them. The fatal hook does not run either. Code after
+	// such a call then keeps running in a state it assumed it would never
+	// reach, so do not set this in production.
+	DisableExitOnFatal bool
+
+	// PruneOnInit removes rotated backups of the log files that exceed
+	// LogFileMaxBackups or LogFileMaxAgeDays during Initialize, before the files
+	// are opened, so lumberjack's first rotation does not have to. At most
+	// 100000 directory entries are scanned. Failures are reported on stderr and
+	// do not fail Initialize.
+	PruneOnInit bool
+
+	// BreakerThreshold is the number of errors within BreakerWindowMS that
+	// opens a LogBreaker (see Service.Breaker). Zero uses 5.
+	BreakerThreshold int
+
+	// BreakerWindowMS is the window a LogBreaker counts errors in, and the quiet
+	// time after which an open breaker closes. Zero uses 10000 (10s).
+	BreakerWindowMS int
+
+	// MaxLineBytes, when > 0, caps the size of each serialized line. Oversized
+	// JSON lines have their longest string values truncated (and, if that is not
+	// enough, their largest fields dropped) and carry _truncated and
+	// _original_bytes markers; the result is still valid JSON. Minimum 128.
+	MaxLineBytes int
+
+	// LogFileName overrides the log file name derived from the executable
+	// (<exe>.log), e.g. for several instances sharing RelLogFileDir. It is a
+	// plain file name; the file is still placed under RelLogFileDir.
+	LogFileName string
+
+	// IncludeGoroutineID adds a goroutine_id field to every event, to correlate
+	// lines while investigating deadlocks. Go does not expose the ID, so it is
+	// parsed from a stack trace on each event (about a microsecond); keep it off
+	// in production.
+	IncludeGoroutineID bool
+
+	// IncludePID and IncludeHost add the process ID (pid) and host name (host)
+	// to every line, for aggregation across hosts. The host name is resolved
+	// once per process; if it cannot be resolved the host field is omitted.
+	IncludePID  bool
+	IncludeHost bool
+
+	// EmitNumericSeverity adds a severity field with the syslog severity number
+	// (0-7) of each event next to the textual level: trace and debug 7, info and
+	// level-less events 6, warn 4, error 3, fatal 2, panic 0.
+	EmitNumericSeverity bool
+
+	// WarnOnUninitialized writes a one-time notice to stderr the first time an
+	// event is dropped because the service is not initialized (or already
+	// closed), instead of dropping it silently.
+	WarnOnUninitialized bool
+
+	// WriteTimeoutMS, when > 0, hands lines to a background goroutine through a
+	// bounded queue so that a slow output does not block the caller. When the
+	// queue is full, a line is dropped (see DroppedWrites) if it cannot be queued
+	// within this timeout. Close waits until the queued lines are written, up to
+	// its own deadline; lines still queued then are dropped as well.
+	WriteTimeoutMS int
+
+	// FifoPath, when set, also writes every line to this named pipe (relative
+	// to WorkingDir unless absolute), e.g. for a log collection sidecar. The pipe
+	// is created if it does not exist. Writes never block: lines are dropped
+	// while no reader has the pipe open, and held back (up to 64 KiB, then
+	// dropped) while the reader is slow; see DroppedFifoLines. Unix only.
+	FifoPath string
+
+	// StrictFieldKeys renames fields added through LogEvent's typed methods whose
+	// key collides with the level, message or timestamp field (under the default
+	// or configured zerolog names) to <key>_field, so that lines never carry
+	// duplicate keys. The first rename of each key is reported with a Warn line.
+	// Intended for development.
+	StrictFieldKeys bool
+
+	// ServiceIdentity, when set, is written as a service field on every line
+	// (including those of context loggers) to tell the services of a
+	// multi-service binary apart. Name returns it.
+	ServiceIdentity string
+
+	// AttachBreadcrumbs records the trail passed to Breadcrumb (the last 20
+	// entries) and attaches it as a breadcrumbs array to every event given an
+	// error through Err.
+	AttachBreadcrumbs bool
+
+	// DumpUnexported makes Dump include unexported struct fields, read through
+	// reflect and unsafe on a best-effort basis, with " (unexported)" after their
+	// name. By default they are skipped. Intended for debugging.
+	DumpUnexported bool
+}
+
+// clone returns a copy of c that shares no slices or pointers with it.
+func (c Config) clone() Config {
+	if c.ErrorOpsAllowPrefix != nil {
+		c.ErrorOpsAllowPrefix = append([]string(nil), c.ErrorOpsAllowPrefix...)
+	}
+	if c.FieldOrder != nil {
+		c.FieldOrder = append([]string(nil), c.FieldOrder...)
+	}
+	if c.FallbackToStderr != nil {
+		v := *c.FallbackToStderr
+		c.FallbackToStderr = &v
+	}
+	return c
+}
+
+// errorEnrichmentMode is the parsed form of Config.ErrorEnrichment.
+type errorEnrichmentMode int32
+
+const (
+	enrichmentFull errorEnrichmentMode = iota
+	enrichmentRootOnly
+	enrichmentOff
+)
+
+// parseErrorEnrichment maps the configured string to an errorEnrichmentMode.
+// An empty string selects full enrichment for backward compatibility.
+func parseErrorEnrichment(mode string) (errorEnrichmentMode, bool) {
+	switch mode {
+	case emptyString, ErrorEnrichmentFull:
+		return enrichmentFull, true
+	case ErrorEnrichmentRootOnly:
+		return enrichmentRootOnly, true
+	case ErrorEnrichmentOff:
+		return enrichmentOff, true
+	default:
+		return enrichmentFull, false
+	}
+}
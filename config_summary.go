@@ -0,0 +1,53 @@
+package logging
+
+// LogConfigSummary writes one Info line describing the effective logging
+// configuration: level, enabled outputs, log directory and files, rotation
+// limits and sampling. The configured level is written as log_level, since
+// level holds the line's own level. Like any Info line it is dropped when Level
+// is above info. It does nothing before Initialize or after Close. The
+// configuration holds no secrets, so nothing is redacted.
+func (s *Service) LogConfigSummary() {
+	if s == nil || !s.isInitialized.Load() {
+		return
+	}
+
+	s.mu.RLock()
+	if s.LoggingConfig == nil {
+		s.mu.RUnlock()
+		return
+	}
+	cfg := *s.LoggingConfig
+	var logFile, errFile string
+	if s.fileWriter != nil {
+		logFile = s.fileWriter.Filename
+	}
+	if s.errFileWriter != nil {
+		errFile = s.errFileWriter.Filename
+	}
+	s.mu.RUnlock()
+
+	e := s.InfoWith().
+		Str("log_level", cfg.Level).
+		Bool("console_logging", cfg.ConsoleLogging).
+		Bool("file_logging", logFile != emptyString)
+	if logFile != emptyString {
+		e.Str("log_file", logFile).
+			Int("max_size_mb", cfg.LogFileMaxSizeMB).
+			Int("max_backups", cfg.LogFileMaxBackups).
+			Int("max_age_days", cfg.LogFileMaxAgeDays).
+			Bool("compress", cfg.LogFileCompress)
+	}
+	if errFile != emptyString {
+		e.Str("error_file", errFile)
+	}
+	if s.Config.SampleBurst > 0 {
+		mode := s.Config.SampleMode
+		if mode == emptyString {
+			mode = SampleModeBurst
+		}
+		e.Int("sample_burst", s.Config.SampleBurst).
+			Int("sample_period_ms", s.Config.SamplePeriodMS).
+			Str("sample_mode", mode)
+	}
+	e.Msg("logging configuration")
+}
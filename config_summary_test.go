@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogConfigSummary(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.Level = "info"
+	service, dir := newFileTestService(t, cfg, Config{SampleBurst: 100, SamplePeriodMS: 1000})
+
+	service.LogConfigSummary()
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "logging configuration", entry["message"])
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, "info", entry["log_level"])
+	assert.Equal(t, false, entry["console_logging"])
+	assert.Equal(t, true, entry["file_logging"])
+	assert.Equal(t, service.fileWriter.Filename, entry["log_file"])
+	assert.Equal(t, float64(cfg.LogFileMaxSizeMB), entry["max_size_mb"])
+	assert.Equal(t, float64(cfg.LogFileMaxBackups), entry["max_backups"])
+	assert.Equal(t, float64(cfg.LogFileMaxAgeDays), entry["max_age_days"])
+	assert.Equal(t, float64(100), entry["sample_burst"])
+	assert.Equal(t, SampleModeBurst, entry["sample_mode"])
+	assert.NotContains(t, entry, "error_file")
+}
+
+func TestLogConfigSummary_Uninitialized(t *testing.T) {
+	assert.NotPanics(t, func() {
+		(&Service{}).LogConfigSummary()
+		var nilService *Service
+		nilService.LogConfigSummary()
+	})
+}
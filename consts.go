@@ -6,6 +6,25 @@ const (
 	// ServiceName is the DI/service locator name for the logging service.
 	ServiceName = types.LoggingServiceName
 	emptyString = ""
+
+	// errorLogFileName is the file that receives error and above when Config.ErrorFileEnabled is set.
+	errorLogFileName = "errors.log"
+
+	// defaultComponentKey is the field WithComponent uses when Config.ComponentKey is empty.
+	defaultComponentKey = "component"
+
+	// goroutineIDFieldName is the field written when Config.IncludeGoroutineID is set.
+	goroutineIDFieldName = "goroutine_id"
+
+	// pidFieldName and hostFieldName are written when Config.IncludePID/IncludeHost are set.
+	pidFieldName  = "pid"
+	hostFieldName = "host"
+	// serviceFieldName holds Config.ServiceIdentity.
+	serviceFieldName = "service"
+	// severityFieldName is the field written when Config.EmitNumericSeverity is set.
+	severityFieldName = "severity"
+	// base64LenSuffix is appended to the key of LogEvent.Base64 for the length field.
+	base64LenSuffix = "_len"
 )
 
 const (
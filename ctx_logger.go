@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// CtxLogger returns a context logger bound to ctx. Every event it creates checks
+// ctx.Err() at creation time; once the context is cancelled or has expired, the
+// event carries ctx_err and, if the context has a deadline, ctx_deadline. Child
+// loggers derived through With() keep the binding.
+// Returns a no-op logger if the service is not initialized.
+func (s *Service) CtxLogger(ctx context.Context) Logger {
+	return s.newCtxLogger(ctx, noDeadlineFields)
+}
+
+// WithDeadline returns a context logger bound to ctx, like CtxLogger, that
+// also reports how close ctx is to its deadline: every event carries
+// deadline_remaining_ms while ctx has a deadline, plus deadline_near=true once
+// the remaining time is within warnMargin. A zero warnMargin only sets
+// deadline_near once the deadline has passed.
+// Returns a no-op logger if the service is not initialized.
+func (s *Service) WithDeadline(ctx context.Context, warnMargin time.Duration) Logger {
+	return s.newCtxLogger(ctx, max(warnMargin, 0))
+}
+
+// noDeadlineFields is the margin of a CtxLogger, which writes no deadline fields.
+const noDeadlineFields time.Duration = -1
+
+// newCtxLogger implements CtxLogger and WithDeadline; a negative margin
+// (noDeadlineFields) disables the deadline fields.
+func (s *Service) newCtxLogger(ctx context.Context, margin time.Duration) Logger {
+	if s == nil || !s.isInitialized.Load() || ctx == nil {
+		return &noopLogger{}
+	}
+
+	// Acquire read lock to prevent Close() from running
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.isInitialized.Load() {
+		return &noopLogger{}
+	}
+
+	logger := s.logger.Load()
+	if logger == nil {
+		return &noopLogger{}
+	}
+	return &contextLogger{
+		logger: logger,
+		parent: s,
+		ctx:    ctx,
+		margin: margin,
+	}
+}
+
+// appendCtxFields adds ctx_err and ctx_deadline to event if ctx is done.
+func appendCtxFields(event *zerolog.Event, ctx context.Context) *zerolog.Event {
+	err := ctx.Err()
+	if err == nil {
+		return event
+	}
+	event = event.Str("ctx_err", err.Error())
+	if deadline, ok := ctx.Deadline(); ok {
+		event = event.Time("ctx_deadline", deadline)
+	}
+	return event
+}
+
+// appendDeadlineFields adds deadline_remaining_ms if ctx has a deadline, and
+// deadline_near when it is at most margin away or already passed.
+func appendDeadlineFields(event *zerolog.Event, ctx context.Context, margin time.Duration) *zerolog.Event {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return event
+	}
+	remaining := time.Until(deadline)
+	event = event.Int64("deadline_remaining_ms", remaining.Milliseconds())
+	if remaining <= margin {
+		event = event.Bool("deadline_near", true)
+	}
+	return event
+}
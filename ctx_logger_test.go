@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCtxLogger(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reqLogger := service.CtxLogger(ctx)
+	childLogger := reqLogger.With().Str("request_id", "r1").Logger()
+
+	reqLogger.InfoWith().Msg("before cancel")
+	cancel()
+	reqLogger.InfoWith().Msg("after cancel")
+	childLogger.WarnWith().Msg("child after cancel")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 3)
+	assert.NotContains(t, entries[0], "ctx_err")
+	assert.Equal(t, context.Canceled.Error(), entries[1]["ctx_err"])
+	assert.NotContains(t, entries[1], "ctx_deadline")
+	assert.Equal(t, context.Canceled.Error(), entries[2]["ctx_err"])
+	assert.Equal(t, "r1", entries[2]["request_id"])
+	assert.Equal(t, int32(0), service.activeOps.Load())
+}
+
+func TestCtxLogger_Deadline(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	service.CtxLogger(ctx).ErrorWith().Msg("expired")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, context.DeadlineExceeded.Error(), entries[0]["ctx_err"])
+	assert.Contains(t, entries[0], "ctx_deadline")
+}
+
+func TestCtxLogger_Uninitialized(t *testing.T) {
+	service := &Service{}
+	assert.IsType(t, &noopLogger{}, service.CtxLogger(context.Background()))
+}
+
+func TestWithDeadline(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	nearCtx, cancelNear := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelNear()
+	farCtx, cancelFar := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelFar()
+
+	service.WithDeadline(nearCtx, time.Second).InfoWith().Msg("near")
+	farLogger := service.WithDeadline(farCtx, time.Second)
+	farLogger.With().Str("job", "j1").Logger().InfoWith().Msg("far")
+	service.WithDeadline(context.Background(), time.Second).InfoWith().Msg("no deadline")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 3)
+
+	near := entries[0]
+	assert.Equal(t, true, near["deadline_near"])
+	assert.LessOrEqual(t, near["deadline_remaining_ms"], float64(50))
+
+	far := entries[1]
+	assert.NotContains(t, far, "deadline_near")
+	assert.Greater(t, far["deadline_remaining_ms"], float64(time.Minute.Milliseconds()))
+	assert.Equal(t, "j1", far["job"])
+
+	assert.NotContains(t, entries[2], "deadline_remaining_ms")
+	assert.NotContains(t, entries[2], "deadline_near")
+}
+
+func TestWithDeadline_ZeroMargin(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	service.WithDeadline(ctx, 0).InfoWith().Msg("running")
+	service.WithDeadline(expired, 0).InfoWith().Msg("expired")
+	service.CtxLogger(ctx).InfoWith().Msg("plain")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 3)
+	assert.Greater(t, entries[0]["deadline_remaining_ms"], float64(time.Minute.Milliseconds()))
+	assert.NotContains(t, entries[0], "deadline_near")
+	assert.Equal(t, true, entries[1]["deadline_near"])
+	assert.NotContains(t, entries[2], "deadline_remaining_ms")
+}
@@ -0,0 +1,164 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// dedupeFieldName is the field added to the next written line when identical
+// lines were suppressed before it.
+const dedupeFieldName = "duplicate_suppressed"
+
+// maxDedupeLines bounds the number of distinct lines a dedupeWriter remembers
+// within one window; once full, new lines are written without being remembered.
+const maxDedupeLines = 10000
+
+// dedupeWriter suppresses lines identical to any line written within a time
+// window (ignoring the timestamp), so that alternating duplicates are caught
+// too. The number of suppressed lines is reported as a duplicate_suppressed
+// field on the next line that is written, or by flush.
+type dedupeWriter struct {
+	mu           sync.Mutex
+	w            zerolog.LevelWriter
+	window       time.Duration
+	seen         map[uint64]time.Time // Line hash -> when it was written
+	sweptAt      time.Time            // Last time expired entries were removed from seen
+	suppressed   int
+	pending      []byte        // Last suppressed line, written by flush
+	pendingLevel zerolog.Level // Level of pending
+}
+
+// newDedupeWriter wraps w with duplicate suppression over the given window.
+func newDedupeWriter(w zerolog.LevelWriter, window time.Duration) *dedupeWriter {
+	return &dedupeWriter{w: w, window: window, seen: make(map[uint64]time.Time)}
+}
+
+// Write implements io.Writer.
+func (dw *dedupeWriter) Write(p []byte) (int, error) {
+	return dw.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+func (dw *dedupeWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	hash := lineHash(level, p)
+	now := time.Now()
+
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	dw.sweep(now)
+	if written, ok := dw.seen[hash]; ok && now.Sub(written) < dw.window {
+		dw.suppressed++
+		// zerolog reuses p once Write returns
+		dw.pending = append(dw.pending[:0], p...)
+		dw.pendingLevel = level
+		return len(p), nil
+	}
+
+	if _, ok := dw.seen[hash]; ok || len(dw.seen) < maxDedupeLines {
+		dw.seen[hash] = now
+	}
+	if dw.suppressed == 0 {
+		return dw.w.WriteLevel(level, p)
+	}
+
+	out := appendSuppressedCount(p, dw.suppressed)
+	dw.suppressed = 0
+	dw.pending = dw.pending[:0]
+	if _, err := dw.w.WriteLevel(level, out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// sweep removes the lines written more than a window ago, at most once per
+// window. The caller must hold dw.mu.
+func (dw *dedupeWriter) sweep(now time.Time) {
+	if now.Sub(dw.sweptAt) < dw.window {
+		return
+	}
+	dw.sweptAt = now
+	for hash, written := range dw.seen {
+		if now.Sub(written) >= dw.window {
+			delete(dw.seen, hash)
+		}
+	}
+}
+
+// flush writes out the count of lines suppressed since the last written line,
+// which would otherwise be lost when no further line follows. The last
+// suppressed line itself is written, carrying the number suppressed before it.
+func (dw *dedupeWriter) flush() error {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+	if dw.suppressed == 0 {
+		return nil
+	}
+	out := dw.pending
+	if dw.suppressed > 1 {
+		out = appendSuppressedCount(out, dw.suppressed-1)
+	}
+	dw.suppressed = 0
+	dw.pending = nil
+	_, err := dw.w.WriteLevel(dw.pendingLevel, out)
+	return err
+}
+
+// lineHash hashes the level and the JSON line with the timestamp field removed.
+// Lines that are not JSON objects are hashed verbatim.
+//
+// The line is decoded once into its top-level fields (values stay raw) so that
+// the timestamp can be dropped wherever it appears and the remaining fields can
+// be hashed in key order. This is the main cost of DedupeWindowMS, which is why
+// it is opt-in.
+func lineHash(level zerolog.Level, p []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte{byte(level)})
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(p, &fields); err != nil {
+		_, _ = h.Write(p)
+		return h.Sum64()
+	}
+	delete(fields, zerolog.TimestampFieldName)
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		// The separators keep different key/value splits from hashing equally
+		_, _ = h.Write([]byte(key))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write(fields[key])
+		_, _ = h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// appendSuppressedCount inserts the duplicate_suppressed field before the
+// closing brace of a JSON line. Non-JSON lines are returned unchanged.
+func appendSuppressedCount(p []byte, count int) []byte {
+	end := bytes.LastIndexByte(p, '}')
+	if end < 0 {
+		return p
+	}
+	out := make([]byte, 0, len(p)+len(dedupeFieldName)+16)
+	out = append(out, p[:end]...)
+	if head := bytes.TrimSpace(p[:end]); len(head) > 0 && head[len(head)-1] != '{' {
+		out = append(out, ',')
+	}
+	out = append(out, '"')
+	out = append(out, dedupeFieldName...)
+	out = append(out, '"', ':')
+	out = strconv.AppendInt(out, int64(count), 10)
+	out = append(out, p[end:]...)
+	return out
+}
@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/logging/logtest"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupeWindow(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.WithTimestamp = true
+	service, dir := newFileTestService(t, cfg, Config{DedupeWindowMS: 60_000})
+
+	for i := 0; i < 5; i++ {
+		service.WarnWith().Str("port", "COM3").Msg("serial port unavailable")
+	}
+	service.WarnWith().Str("port", "COM4").Msg("serial port unavailable")
+	service.InfoWith().Msg("recovered")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 3)
+	assert.Equal(t, "COM3", entries[0]["port"])
+	assert.NotContains(t, entries[0], dedupeFieldName)
+	assert.Equal(t, "COM4", entries[1]["port"])
+	assert.Equal(t, float64(4), entries[1][dedupeFieldName])
+	assert.NotContains(t, entries[2], dedupeFieldName)
+	assert.Equal(t, int32(0), service.activeOps.Load())
+}
+
+func TestAppendSuppressedCount(t *testing.T) {
+	assert.Equal(t, `{"a":1,"duplicate_suppressed":3}`+"\n",
+		string(appendSuppressedCount([]byte(`{"a":1}`+"\n"), 3)))
+	assert.Equal(t, `{"duplicate_suppressed":2}`, string(appendSuppressedCount([]byte(`{}`), 2)))
+	assert.Equal(t, "plain\n", string(appendSuppressedCount([]byte("plain\n"), 2)))
+}
+
+func TestDedupeWindow_CloseFlushesPendingCount(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{DedupeWindowMS: 60_000})
+	name := logFileName(service)
+
+	for i := 0; i < 5; i++ {
+		service.WarnWith().Str("port", "COM3").Msg("serial port unavailable")
+	}
+	require.NoError(t, service.Close())
+
+	entries := readLogEntries(t, dir, name)
+	require.Len(t, entries, 2)
+	assert.NotContains(t, entries[0], dedupeFieldName)
+	assert.Equal(t, "warn", entries[1]["level"])
+	assert.Equal(t, "COM3", entries[1]["port"])
+	assert.Equal(t, float64(3), entries[1][dedupeFieldName])
+}
+
+func TestDedupeWindow_AlternatingLines(t *testing.T) {
+	var buf threadSafeBuffer
+	dw := newDedupeWriter(zerolog.MultiLevelWriter(&buf), 50*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		_, _ = dw.WriteLevel(zerolog.WarnLevel, []byte(`{"level":"warn","message":"A"}`+"\n"))
+		_, _ = dw.WriteLevel(zerolog.WarnLevel, []byte(`{"level":"warn","message":"B"}`+"\n"))
+	}
+	_, _ = dw.WriteLevel(zerolog.InfoLevel, []byte(`{"level":"info","message":"C"}`+"\n"))
+
+	// Once the window has passed, A is written again
+	time.Sleep(60 * time.Millisecond)
+	_, _ = dw.WriteLevel(zerolog.WarnLevel, []byte(`{"level":"warn","message":"A"}`+"\n"))
+
+	lines, err := logtest.DecodeLines(strings.NewReader(buf.String()))
+	require.NoError(t, err)
+	require.Len(t, lines, 4)
+	assert.Equal(t, "A", lines[0]["message"])
+	assert.Equal(t, "B", lines[1]["message"])
+	assert.Equal(t, "C", lines[2]["message"])
+	assert.Equal(t, float64(4), lines[2][dedupeFieldName])
+	assert.Equal(t, "A", lines[3]["message"])
+	assert.NotContains(t, lines[3], dedupeFieldName)
+	assert.Len(t, dw.seen, 1)
+}
@@ -0,0 +1,21 @@
+package logging
+
+// Deprecated emits a Warn line with a deprecated_feature field the first time it
+// is called for a given feature; later calls for the same feature are ignored.
+// A feature only counts as reported once its line was actually written.
+// Example: svc.Deprecated("config.v1", "config v1 is deprecated, migrate to v2")
+func (s *Service) Deprecated(feature string, msg string) {
+	if s == nil || !s.isInitialized.Load() || s.deprecations.has(feature) {
+		return
+	}
+	claimOnce(s.WarnWith(), &s.deprecations, feature).Str("deprecated_feature", feature).Msg(msg)
+}
+
+// ResetDeprecations forgets which deprecation warnings have been emitted so that
+// they fire again. Intended for tests.
+func (s *Service) ResetDeprecations() {
+	if s == nil {
+		return
+	}
+	s.deprecations.reset()
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecated_Dedupe(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	for i := 0; i < 3; i++ {
+		service.Deprecated("X", "X is deprecated, use Y")
+	}
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "warn", entries[0]["level"])
+	assert.Equal(t, "X", entries[0]["deprecated_feature"])
+	assert.Equal(t, "X is deprecated, use Y", entries[0]["message"])
+
+	service.ResetDeprecations()
+	service.Deprecated("X", "X is deprecated, use Y")
+	assert.Len(t, readLogEntries(t, dir, logFileName(service)), 2)
+}
+
+func TestDeprecated_Uninitialized(t *testing.T) {
+	var nilService *Service
+	nilService.Deprecated("X", "no panic")
+	nilService.ResetDeprecations()
+
+	service := &Service{}
+	service.Deprecated("X", "no panic")
+}
+
+func TestDeprecated_MarkedOnlyWhenWritten(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	// A line dropped by SetQuiet or the filter does not use up the feature
+	service.SetQuiet(true)
+	service.Deprecated("config.v1", "config v1 is deprecated")
+	service.SetQuiet(false)
+	service.SetFilter(func(zerolog.Level, string) bool { return false })
+	service.Deprecated("config.v1", "config v1 is deprecated")
+	service.SetFilter(nil)
+	service.Deprecated("config.v1", "config v1 is deprecated")
+	service.Deprecated("config.v1", "config v1 is deprecated")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "config.v1", entries[0]["deprecated_feature"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
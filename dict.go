@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"bytes"
+
+	"github.com/rs/zerolog"
+)
+
+// WithDict returns a child logger whose every line carries the fields added by
+// build nested under key as a JSON object, e.g. {"request":{"id":"r1","route":"/v1"}}.
+// build receives an empty LogContext and returns it after adding fields; the
+// same typed methods (including Err enrichment) as With() are available.
+// Returns a no-op logger if the service is not initialized.
+func (s *Service) WithDict(key string, build func(LogContext) LogContext) Logger {
+	parent, ok := s.With().(*logContext)
+	if !ok {
+		return &noopLogger{}
+	}
+	if build != nil {
+		if raw, ok := buildDict(s, build); ok {
+			parent.add(key, func(zc zerolog.Context) zerolog.Context { return zc.RawJSON(key, raw) })
+		}
+	}
+	return parent.Logger()
+}
+
+// buildDict renders the fields added by build as a JSON object. The fields are
+// written once, through a throwaway logger, so that every LogContext method
+// can be reused for nested objects.
+func buildDict(s *Service, build func(LogContext) LogContext) ([]byte, bool) {
+	var buf bytes.Buffer
+	scratch := &logContext{context: zerolog.New(&buf).With(), service: s}
+	result, ok := build(scratch).(*logContext)
+	if !ok {
+		return nil, false
+	}
+	logger := result.context.Logger()
+	logger.Log().Send()
+	return bytes.TrimSpace(buf.Bytes()), true
+}
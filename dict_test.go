@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDict(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	reqLogger := service.WithDict("request", func(c LogContext) LogContext {
+		return c.Str("id", "r1").Str("route", "/v1/items").Int("attempt", 2)
+	})
+	reqLogger.InfoWith().Msg("first")
+	reqLogger.With().Str("user_id", "u1").Logger().WarnWith().Int("status", 404).Msg("second")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		request, ok := entry["request"].(map[string]interface{})
+		require.True(t, ok, "request should be a nested object")
+		assert.Equal(t, map[string]interface{}{"id": "r1", "route": "/v1/items", "attempt": float64(2)}, request)
+		assert.NotContains(t, entry, "id")
+	}
+	assert.Equal(t, "u1", entries[1]["user_id"])
+	assert.Equal(t, float64(404), entries[1]["status"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestWithDict_Uninitialized(t *testing.T) {
+	logger := (&Service{}).WithDict("request", func(c LogContext) LogContext { return c.Str("id", "r1") })
+	require.NotNil(t, logger)
+	logger.InfoWith().Msg("dropped")
+}
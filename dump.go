@@ -1,8 +1,10 @@
 package logging
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"unsafe"
 
 	"github.com/rs/zerolog"
 )
@@ -19,12 +21,8 @@ func (s *Service) Dump(v interface{}) {
 	}
 
 	// Increment active operations counter
-	s.activeOps.Add(1)
-	s.wg.Add(1)
-	defer func() {
-		s.activeOps.Add(-1)
-		s.wg.Done()
-	}()
+	s.trackOp()
+	defer s.releaseOp(emptyString)
 
 	// Acquire read lock to prevent Close() from running
 	s.mu.RLock()
@@ -52,16 +50,100 @@ func (s *Service) Dump(v interface{}) {
 	defer s.mu.RUnlock()
 
 	// Use a map to track visited pointers to prevent infinite recursion
-	visited := make(map[uintptr]bool)
+	visited := make(map[dumpVisit]bool)
 	s.dumpValue(logger, v, "", visited, 0)
 }
 
+// RegisterDumper registers a formatter used by Dump and LogEvent.Interface for
+// values of type t, e.g. to print a custom enum by name instead of its number.
+// Registering nil removes the formatter. It is safe for concurrent use.
+// Without a registered formatter, scalar values (numbers, strings, bools) whose
+// type implements fmt.Stringer but not json.Marshaler, such as time.Duration,
+// are written with their String method.
+// Example: svc.RegisterDumper(reflect.TypeOf(StatusActive), func(v interface{}) string { return v.(Status).Name() })
+func (s *Service) RegisterDumper(t reflect.Type, fn func(interface{}) string) {
+	if s == nil || t == nil {
+		return
+	}
+	if fn == nil {
+		if _, loaded := s.dumpers.LoadAndDelete(t); loaded {
+			s.dumperCount.Dec()
+		}
+		return
+	}
+	if _, loaded := s.dumpers.Swap(t, fn); !loaded {
+		s.dumperCount.Inc()
+	}
+}
+
+// formatValue formats v with the formatter registered for its dynamic type or,
+// failing that, with its String method (see RegisterDumper). It reports false
+// if neither applies.
+func (s *Service) formatValue(v interface{}) (string, bool) {
+	if str, ok := s.formatRegistered(v); ok {
+		return str, true
+	}
+	return stringerish(v)
+}
+
+// formatRegistered formats v with the formatter registered for its dynamic type.
+// It reports false if v is nil or no formatter is registered.
+func (s *Service) formatRegistered(v interface{}) (string, bool) {
+	if s == nil || v == nil || s.dumperCount.Load() == 0 {
+		return emptyString, false
+	}
+	fn, ok := s.dumpers.Load(reflect.TypeOf(v))
+	if !ok {
+		return emptyString, false
+	}
+	return fn.(func(interface{}) string)(v), true
+}
+
+// stringerish formats scalar values whose type implements fmt.Stringer, which
+// would otherwise be written as bare numbers. Composite values and types with
+// their own JSON encoding keep their usual output.
+func stringerish(v interface{}) (string, bool) {
+	stringer, ok := v.(fmt.Stringer)
+	if !ok {
+		return emptyString, false
+	}
+	if _, ok := v.(json.Marshaler); ok {
+		return emptyString, false
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return stringer.String(), true
+	default:
+		return emptyString, false
+	}
+}
+
+// unexportedMarker follows the name of unexported fields in Dump output.
+const unexportedMarker = " (unexported)"
+
+// unexportedFieldValue returns a readable view of the unexported struct field
+// f, which must be addressable. It bypasses the reflect read-only flag, so the
+// result must only be read.
+func unexportedFieldValue(f reflect.Value) reflect.Value {
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+// dumpVisit identifies a value seen during a Dump. The type is part of the key
+// because a struct and its first field share an address.
+type dumpVisit struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
 // Maximum recursion depth to prevent stack overflow
 const maxDumpDepth = 10
 
 // dumpValue is a recursive helper function for Dump. It unwraps interfaces and pointers safely
 // (with cycle detection) and logs the structure using Debug-level entries.
-func (s *Service) dumpValue(logger *zerolog.Logger, v interface{}, prefix string, visited map[uintptr]bool, depth int) {
+func (s *Service) dumpValue(logger *zerolog.Logger, v interface{}, prefix string, visited map[dumpVisit]bool, depth int) {
 	if depth > maxDumpDepth {
 		logger.Debug().Msgf("%s: <max depth reached>", prefix)
 		return
@@ -76,6 +158,7 @@ func (s *Service) dumpValue(logger *zerolog.Logger, v interface{}, prefix string
 
 	// Safely unwrap interfaces and handle pointers, with cycle detection.
 	// Avoid calling Pointer() on unsupported kinds.
+	derefed := false
 	for {
 		switch val.Kind() {
 		case reflect.Interface:
@@ -91,13 +174,14 @@ func (s *Service) dumpValue(logger *zerolog.Logger, v interface{}, prefix string
 				logger.Debug().Msgf("%s: <nil>", prefix)
 				return
 			}
-			ptr := val.Pointer()
-			if visited[ptr] {
+			key := dumpVisit{addr: val.Pointer(), typ: val.Type().Elem()}
+			if visited[key] {
 				logger.Debug().Msgf("%s: <circular reference>", prefix)
 				return
 			}
-			visited[ptr] = true
+			visited[key] = true
 			val = val.Elem()
+			derefed = true
 		// pointer unwrapped; continue handling concrete kind
 		default:
 			// No-op
@@ -107,16 +191,25 @@ func (s *Service) dumpValue(logger *zerolog.Logger, v interface{}, prefix string
 
 	typ := val.Type()
 
+	// Registered formatters and String methods take precedence over the generic handling
+	if val.CanInterface() {
+		if str, ok := s.formatValue(val.Interface()); ok {
+			logger.Debug().Msgf("%s: %s", prefix, str)
+			return
+		}
+	}
+
 	// For non-pointer addressable values (like structs that are reachable multiple
-	// times by reference), record their address to help detect cycles.
-	if val.CanAddr() {
-		addrPtr := val.Addr().Pointer()
-		if visited[addrPtr] {
+	// times by reference), record their address to help detect cycles. A value
+	// just reached through a pointer was already recorded above.
+	if val.CanAddr() && !derefed {
+		key := dumpVisit{addr: val.Addr().Pointer(), typ: typ}
+		if visited[key] {
 			logger.Debug().Msgf("%s: <circular reference>", prefix)
 			return
 		}
 		// mark addressable value as visited so repeated references won't recurse endlessly
-		visited[addrPtr] = true
+		visited[key] = true
 		// Note: keep this entry; it's fine for the scope of this dump call
 	}
 
@@ -129,21 +222,32 @@ func (s *Service) dumpValue(logger *zerolog.Logger, v interface{}, prefix string
 			logger.Debug().Msgf("%s: %s {", prefix, structName)
 		}
 
+		// Unexported fields can only be read through an addressable value
+		if s.Config.DumpUnexported && !val.CanAddr() {
+			addressable := reflect.New(typ).Elem()
+			addressable.Set(val)
+			val = addressable
+		}
+
 		// Iterate over struct fields
 		for i := 0; i < val.NumField(); i++ {
 			field := typ.Field(i)
 			fieldVal := val.Field(i)
 
-			// Skip unexported fields
-			if !fieldVal.CanInterface() {
-				continue
-			}
-
 			fieldPrefix := field.Name
 			if prefix != "" {
 				fieldPrefix = prefix + "." + field.Name
 			}
 
+			// Skip unexported fields unless DumpUnexported is set
+			if !fieldVal.CanInterface() {
+				if !s.Config.DumpUnexported {
+					continue
+				}
+				fieldVal = unexportedFieldValue(fieldVal)
+				fieldPrefix += unexportedMarker
+			}
+
 			s.dumpValue(logger, fieldVal.Interface(), fieldPrefix, visited, depth+1)
 		}
 
@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStatus int
+
+const (
+	testStatusIdle testStatus = iota
+	testStatusActive
+)
+
+func (s testStatus) name() string {
+	switch s {
+	case testStatusIdle:
+		return "idle"
+	case testStatusActive:
+		return "active"
+	default:
+		return "unknown"
+	}
+}
+
+func TestRegisterDumper(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.RegisterDumper(reflect.TypeOf(testStatus(0)), func(v interface{}) string {
+		return v.(testStatus).name()
+	})
+
+	type job struct {
+		Name   string
+		Status testStatus
+	}
+	service.Dump(job{Name: "sync", Status: testStatusActive})
+	service.InfoWith().Interface("status", testStatusIdle).Interface("count", 3).Msg("interface")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	var messages []string
+	for _, entry := range entries {
+		if msg, ok := entry["message"].(string); ok {
+			messages = append(messages, msg)
+		}
+	}
+	assert.Contains(t, messages, "Status: active")
+	assert.Contains(t, messages, "Name: sync")
+
+	last := entries[len(entries)-1]
+	assert.Equal(t, "idle", last["status"])
+	assert.Equal(t, float64(3), last["count"])
+
+	// Unregistering restores the numeric output
+	service.RegisterDumper(reflect.TypeOf(testStatus(0)), nil)
+	service.InfoWith().Interface("status", testStatusActive).Msg("numeric")
+	entries = readLogEntries(t, dir, logFileName(service))
+	require.NotEmpty(t, entries)
+	assert.Equal(t, float64(1), entries[len(entries)-1]["status"])
+}
+
+func TestDump_Unexported(t *testing.T) {
+	type inner struct {
+		code int
+	}
+	type account struct {
+		Name   string
+		secret string
+		nested inner
+		ptr    *inner
+	}
+	value := account{Name: "ops", secret: "hunter2", nested: inner{code: 7}, ptr: &inner{code: 9}}
+
+	dumpMessages := func(cfg Config, v interface{}) []string {
+		service, dir := newFileTestService(t, validLoggingConfig(), cfg)
+		service.Dump(v)
+		var messages []string
+		for _, entry := range readLogEntries(t, dir, logFileName(service)) {
+			if msg, ok := entry["message"].(string); ok {
+				messages = append(messages, msg)
+			}
+		}
+		return messages
+	}
+
+	t.Run("skipped by default", func(t *testing.T) {
+		messages := dumpMessages(Config{}, value)
+		assert.Contains(t, messages, "Name: ops")
+		for _, msg := range messages {
+			assert.NotContains(t, msg, "hunter2")
+			assert.NotContains(t, msg, "unexported")
+		}
+	})
+
+	t.Run("included when enabled", func(t *testing.T) {
+		messages := dumpMessages(Config{DumpUnexported: true}, value)
+		assert.Contains(t, messages, "Name: ops")
+		assert.Contains(t, messages, "secret (unexported): hunter2")
+		assert.Contains(t, messages, "nested (unexported).code (unexported): 7")
+		assert.Contains(t, messages, "ptr (unexported).code (unexported): 9")
+	})
+
+	t.Run("included through a pointer", func(t *testing.T) {
+		messages := dumpMessages(Config{DumpUnexported: true}, &value)
+		assert.Contains(t, messages, "secret (unexported): hunter2")
+	})
+}
+
+type testBand int
+
+func (b testBand) String() string { return fmt.Sprintf("%dm", b) }
+
+func TestDump_StringerFallback(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.Dump(struct{ Band testBand }{Band: 20})
+	service.InfoWith().
+		Interface("band", testBand(40)).
+		Interface("timeout", 1500*time.Millisecond).
+		Interface("when", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)).
+		Msg("interface")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.NotEmpty(t, entries)
+	var messages []string
+	for _, entry := range entries {
+		if msg, ok := entry["message"].(string); ok {
+			messages = append(messages, msg)
+		}
+	}
+	assert.Contains(t, messages, "Band: 20m")
+
+	last := entries[len(entries)-1]
+	assert.Equal(t, "40m", last["band"])
+	assert.Equal(t, "1.5s", last["timeout"])
+	// json.Marshaler types keep their JSON form
+	assert.Equal(t, "2024-05-01T00:00:00Z", last["when"])
+
+	// A registered formatter wins over String
+	service.RegisterDumper(reflect.TypeOf(testBand(0)), func(v interface{}) string { return "band" })
+	service.InfoWith().Interface("band", testBand(40)).Msg("registered")
+	entries = readLogEntries(t, dir, logFileName(service))
+	assert.Equal(t, "band", entries[len(entries)-1]["band"])
+}
@@ -0,0 +1,67 @@
+package logging
+
+import "github.com/rs/zerolog"
+
+// dynamicField is a field whose value is computed for every event.
+type dynamicField struct {
+	key string
+	fn  func() interface{}
+}
+
+// AddDynamicField registers fn to be called for every event written by the
+// service and its context loggers; its result is attached under key. Use it for
+// values that change between events, such as the current tenant ID. Registering
+// an existing key replaces its function and a nil fn removes it. fn must be
+// safe for concurrent use and cheap, since it runs on every written line; a
+// panicking fn is recovered and its field omitted.
+func (s *Service) AddDynamicField(key string, fn func() interface{}) {
+	if s == nil {
+		return
+	}
+	s.dynamicMu.Lock()
+	defer s.dynamicMu.Unlock()
+
+	var fields []dynamicField
+	if cur := s.dynamicFields.Load(); cur != nil {
+		fields = make([]dynamicField, 0, len(*cur)+1)
+		for _, f := range *cur {
+			if f.key != key {
+				fields = append(fields, f)
+			}
+		}
+	}
+	if fn != nil {
+		fields = append(fields, dynamicField{key: key, fn: fn})
+	}
+	s.dynamicFields.Store(&fields)
+}
+
+// dynamicFieldsHook attaches the service's dynamic fields to every event. The
+// registry is read on each event, so fields added after Initialize apply to
+// existing context loggers as well.
+type dynamicFieldsHook struct {
+	service *Service
+}
+
+// Run implements zerolog.Hook.
+func (h dynamicFieldsHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	fields := h.service.dynamicFields.Load()
+	if fields == nil {
+		return
+	}
+	for _, f := range *fields {
+		if v, ok := callDynamicField(f.fn); ok {
+			e.Interface(f.key, v)
+		}
+	}
+}
+
+// callDynamicField calls fn, reporting false if it panics.
+func callDynamicField(fn func() interface{}) (v interface{}, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return fn(), true
+}
@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestAddDynamicField(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	var counter atomic.Int64
+	service.AddDynamicField("seq", func() interface{} { return counter.Inc() })
+	reqLogger := service.With().Str("request_id", "r1").Logger()
+
+	service.InfoWith().Msg("first")
+	reqLogger.InfoWith().Msg("second")
+	service.DebugWith().Msg("third")
+
+	service.AddDynamicField("seq", nil)
+	service.InfoWith().Msg("removed")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 4)
+	assert.Equal(t, float64(1), entries[0]["seq"])
+	assert.Equal(t, float64(2), entries[1]["seq"])
+	assert.Equal(t, "r1", entries[1]["request_id"])
+	assert.Equal(t, float64(3), entries[2]["seq"])
+	assert.NotContains(t, entries[3], "seq")
+}
+
+func TestAddDynamicField_PanicRecovered(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.AddDynamicField("tenant", func() interface{} { return "acme" })
+	service.AddDynamicField("broken", func() interface{} { panic("boom") })
+
+	assert.NotPanics(t, func() { service.InfoWith().Msg("still written") })
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "acme", entries[0]["tenant"])
+	assert.NotContains(t, entries[0], "broken")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
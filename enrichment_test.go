@@ -0,0 +1,228 @@
+package logging
+
+import (
+	stderrs "errors"
+	"fmt"
+	"testing"
+
+	smerrors "github.com/Station-Manager/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorEnrichment_Modes(t *testing.T) {
+	inner := smerrors.New("db.Connect").Msg("connection refused")
+	outer := smerrors.New("server.Start").Err(inner).Msg("startup failed")
+
+	tests := []struct {
+		name    string
+		mode    string
+		present []string
+		absent  []string
+	}{
+		{
+			name:    "default is full",
+			mode:    "",
+			present: []string{"error", "error_chain", "error_root", "error_history", "error_ops", "error_root_op"},
+		},
+		{
+			name:    "full",
+			mode:    ErrorEnrichmentFull,
+			present: []string{"error", "error_chain", "error_root", "error_history", "error_ops", "error_root_op"},
+		},
+		{
+			name:    "root-only",
+			mode:    ErrorEnrichmentRootOnly,
+			present: []string{"error", "error_root", "error_root_op"},
+			absent:  []string{"error_chain", "error_history", "error_ops"},
+		},
+		{
+			name:    "off",
+			mode:    ErrorEnrichmentOff,
+			present: []string{"error"},
+			absent:  []string{"error_chain", "error_root", "error_history", "error_ops", "error_root_op"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, dir := newFileTestService(t, validLoggingConfig(), Config{ErrorEnrichment: tt.mode})
+
+			service.ErrorWith().Err(outer).Msg("failed")
+			service.ErrorWith().AnErr("db_err", outer).Msg("failed named")
+
+			entries := readLogEntries(t, dir, logFileName(service))
+			require.Len(t, entries, 2)
+
+			for _, key := range tt.present {
+				assert.Contains(t, entries[0], key)
+				assert.Contains(t, entries[1], "db_err"+key[len("error"):])
+			}
+			for _, key := range tt.absent {
+				assert.NotContains(t, entries[0], key)
+				assert.NotContains(t, entries[1], "db_err"+key[len("error"):])
+			}
+		})
+	}
+}
+
+func TestErrorEnrichment_InvalidMode(t *testing.T) {
+	service := &Service{
+		WorkingDir:    t.TempDir(),
+		ConfigService: newTestConfigService(validLoggingConfig()),
+		Config:        Config{ErrorEnrichment: "verbose"},
+	}
+
+	err := service.Initialize()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validateLocalConfig")
+}
+
+func TestErrNoChain(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	inner := smerrors.New("db.Connect").Msg("connection refused")
+	outer := smerrors.New("server.Start").Err(inner).Msg("startup failed")
+
+	service.ErrorWith().ErrNoChain(outer).Msg("noise")
+	service.With().Str("ctx", "x").Logger().ErrorWith().ErrNoChain(outer).Msg("noise from child")
+	newLogEvent(nil).ErrNoChain(outer).Msg("nil event is safe")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, "startup failed", entry["error"])
+		assert.NotContains(t, entry, "error_chain")
+		assert.NotContains(t, entry, "error_root")
+	}
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestErrorOpsAllowPrefix(t *testing.T) {
+	allow := []string{"server.", "db."}
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{ErrorOpsAllowPrefix: allow})
+	// Later changes to the caller's slice do not affect the running service
+	allow[0] = "other."
+
+	root := smerrors.New("internal.dial").Msg("connection refused")
+	inner := smerrors.New("db.Connect").Err(root).Msg("connect failed")
+	middle := smerrors.New("internal.retry").Err(inner).Msg("retries exhausted")
+	outer := smerrors.New("server.Start").Err(middle).Msg("startup failed")
+
+	service.ErrorWith().Err(outer).Msg("failed")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, []any{"server.Start", "", "db.Connect", ""}, entries[0]["error_ops"])
+	assert.Len(t, entries[0]["error_chain"], 4)
+	assert.NotContains(t, entries[0], "error_root_op")
+}
+
+func TestErrorHistorySeparator(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{ErrorHistorySeparator: " | "})
+
+	err := fmt.Errorf("save: %w", fmt.Errorf("write: %w", stderrs.New("disk full")))
+	service.ErrorWith().Err(err).AnErr("cleanup_err", err).Msg("failed")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "save: write: disk full | write: disk full | disk full", entries[0]["error_history"])
+	assert.Equal(t, entries[0]["error_history"], entries[0]["cleanup_err_history"])
+}
+
+func TestErrorHistoryMaxLinks(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{ErrorHistoryMaxLinks: 2})
+
+	var deep error = smerrors.New("l0").Msg("link 0")
+	for i := 1; i < 6; i++ {
+		deep = smerrors.New(smerrors.Op(fmt.Sprintf("l%d", i))).Err(deep).Msgf("link %d", i)
+	}
+	short := smerrors.New("l1").Err(smerrors.New("l0").Msg("link 0")).Msg("link 1")
+
+	service.ErrorWith().Err(deep).AnErr("cause", deep).Msg("deep")
+	service.ErrorWith().Err(short).Msg("short")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "link 5 -> link 4 (+4 more)", entries[0]["error_history"])
+	assert.Equal(t, "link 5 -> link 4 (+4 more)", entries[0]["cause_history"])
+	assert.Len(t, entries[0]["error_chain"], 6, "error_chain keeps every link")
+	assert.Equal(t, "link 1 -> link 0", entries[1]["error_history"])
+}
+
+func TestJoinChain(t *testing.T) {
+	chain := []string{"a", "b", "c"}
+	assert.Equal(t, "", joinChain(nil, "", 0))
+	assert.Equal(t, "a -> b -> c", joinChain(chain, "", 0))
+	assert.Equal(t, "a/b/c", joinChain(chain, "/", 3))
+	assert.Equal(t, "a (+2 more)", joinChain(chain, "", 1))
+}
+
+func TestConfigClone(t *testing.T) {
+	cfg := Config{ErrorOpsAllowPrefix: []string{"server."}}
+	cp := cfg.clone()
+	cp.ErrorOpsAllowPrefix[0] = "db."
+	assert.Equal(t, "server.", cfg.ErrorOpsAllowPrefix[0])
+	assert.Nil(t, Config{}.clone().ErrorOpsAllowPrefix)
+}
+
+// portError is a custom error type used to check error_type reporting.
+type portError struct{ port string }
+
+func (e *portError) Error() string { return "port " + e.port + " unavailable" }
+
+func TestErrorIncludeType(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{ErrorIncludeType: true})
+
+	root := &portError{port: "COM3"}
+	wrapped := fmt.Errorf("open radio: %w", root)
+
+	service.ErrorWith().Err(root).Msg("bare")
+	service.ErrorWith().Err(wrapped).Msg("wrapped")
+	service.ErrorWith().AnErr("cat_err", wrapped).Msg("named")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "*logging.portError", entries[0]["error_type"])
+	assert.NotContains(t, entries[0], "error_root_type")
+
+	assert.Equal(t, "*fmt.wrapError", entries[1]["error_type"])
+	assert.Equal(t, "*logging.portError", entries[1]["error_root_type"])
+
+	assert.Equal(t, "*fmt.wrapError", entries[2]["cat_err_type"])
+	assert.Equal(t, "*logging.portError", entries[2]["cat_err_root_type"])
+}
+
+func TestErrorIncludeType_OffByDefault(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	service.ErrorWith().Err(&portError{port: "COM3"}).Msg("bare")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.NotContains(t, entries[0], "error_type")
+}
+
+func TestErrorEnrichment_ContextLoggerInheritsMode(t *testing.T) {
+	inner := smerrors.New("db.Connect").Msg("connection refused")
+	outer := smerrors.New("server.Start").Err(inner).Msg("startup failed")
+
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{ErrorEnrichment: ErrorEnrichmentRootOnly})
+
+	child := service.With().Str("request_id", "r1").Logger()
+	child.ErrorWith().Err(outer).Msg("event error")
+
+	scoped := service.With().Err(outer).Logger()
+	scoped.WarnWith().Msg("context error")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, "startup failed", entry["error"])
+		assert.Equal(t, "connection refused", entry["error_root"])
+		assert.Equal(t, "db.Connect", entry["error_root_op"])
+		assert.NotContains(t, entry, "error_chain")
+		assert.NotContains(t, entry, "error_history")
+		assert.NotContains(t, entry, "error_ops")
+	}
+}
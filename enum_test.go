@@ -0,0 +1,33 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogEvent_Enum(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	allowed := []string{"online", "offline"}
+
+	service.InfoWith().Enum("status", "online", allowed).Msg("valid")
+	service.InfoWith().Enum("status", "onlien", allowed).Msg("invalid")
+	newLogEvent(nil).Enum("status", "onlien", allowed).Msg("nil event is safe")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "online", entries[0]["status"])
+	assert.NotContains(t, entries[0], "status_invalid")
+
+	// The debug notice is written while the invalid event is still open
+	assert.Equal(t, "debug", entries[1]["level"])
+	assert.Equal(t, "status", entries[1]["enum_key"])
+	assert.Equal(t, "onlien", entries[1]["enum_value"])
+
+	assert.Equal(t, "invalid", entries[2]["message"])
+	assert.Equal(t, "onlien", entries[2]["status"])
+	assert.Equal(t, true, entries[2]["status_invalid"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/Station-Manager/errors"
+)
+
+// Environment variables read by ApplyEnvOverrides.
+const (
+	EnvLogLevel   = "SM_LOG_LEVEL"   // overrides LoggingConfig.Level
+	EnvLogConsole = "SM_LOG_CONSOLE" // overrides LoggingConfig.ConsoleLogging (strconv.ParseBool syntax)
+	EnvLogFile    = "SM_LOG_FILE"    // overrides LoggingConfig.FileLogging (strconv.ParseBool syntax)
+	EnvLogDir     = "SM_LOG_DIR"     // overrides LoggingConfig.RelLogFileDir
+)
+
+// ApplyEnvOverrides overrides LoggingConfig fields from the SM_LOG_LEVEL,
+// SM_LOG_CONSOLE, SM_LOG_FILE and SM_LOG_DIR environment variables when they are
+// set (to a non-empty value), so environment settings take precedence over the
+// configuration file. Initialize calls it before validating the configuration;
+// calling it afterwards has no effect on the running logger. An invalid value is
+// returned as an error and leaves LoggingConfig unchanged.
+func (s *Service) ApplyEnvOverrides() error {
+	const op errors.Op = "logging.Service.ApplyEnvOverrides"
+	if s == nil {
+		return errors.New(op).Msg(errMsgNilService)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.LoggingConfig == nil {
+		return errors.New(op).Msg(errMsgNilConfig)
+	}
+
+	cfg := *s.LoggingConfig
+	if v := os.Getenv(EnvLogLevel); v != emptyString {
+		if _, err := parseLevel(v); err != nil {
+			return errors.New(op).Errorf("%s: %w", EnvLogLevel, err)
+		}
+		cfg.Level = v
+	}
+	if v := os.Getenv(EnvLogConsole); v != emptyString {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New(op).Errorf("%s: %w", EnvLogConsole, err)
+		}
+		cfg.ConsoleLogging = b
+	}
+	if v := os.Getenv(EnvLogFile); v != emptyString {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New(op).Errorf("%s: %w", EnvLogFile, err)
+		}
+		cfg.FileLogging = b
+	}
+	if v := os.Getenv(EnvLogDir); v != emptyString {
+		cfg.RelLogFileDir = v
+	}
+
+	*s.LoggingConfig = cfg
+	return nil
+}
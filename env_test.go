@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEnvOverrides_LevelOverFile(t *testing.T) {
+	t.Setenv(EnvLogLevel, "warn")
+	cfg := validLoggingConfig()
+	cfg.Level = "debug"
+	service, dir := newFileTestService(t, cfg, Config{})
+	assert.Equal(t, "warn", service.LoggingConfig.Level)
+
+	service.DebugWith().Msg("filtered")
+	service.InfoWith().Msg("filtered too")
+	service.WarnWith().Msg("kept")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "kept", entries[0]["message"])
+}
+
+func TestApplyEnvOverrides_Fields(t *testing.T) {
+	t.Setenv(EnvLogConsole, "true")
+	t.Setenv(EnvLogFile, "0")
+	t.Setenv(EnvLogDir, "envlogs")
+
+	service := &Service{LoggingConfig: validLoggingConfig()}
+	require.NoError(t, service.ApplyEnvOverrides())
+	assert.True(t, service.LoggingConfig.ConsoleLogging)
+	assert.False(t, service.LoggingConfig.FileLogging)
+	assert.Equal(t, "envlogs", service.LoggingConfig.RelLogFileDir)
+}
+
+func TestApplyEnvOverrides_Invalid(t *testing.T) {
+	tests := []struct {
+		name, key, val string
+	}{
+		{name: "level", key: EnvLogLevel, val: "loud"},
+		{name: "console", key: EnvLogConsole, val: "maybe"},
+		{name: "file", key: EnvLogFile, val: "sometimes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.key, tt.val)
+			cfg := validLoggingConfig()
+			service := &Service{
+				WorkingDir:    t.TempDir(),
+				ConfigService: newTestConfigService(cfg),
+			}
+			err := service.Initialize()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.key)
+		})
+	}
+
+	assert.Error(t, (&Service{}).ApplyEnvOverrides())
+}
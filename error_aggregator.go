@@ -0,0 +1,82 @@
+package logging
+
+import "sync"
+
+// ErrorAggregator collects errors from a batch operation so they can be logged
+// as a single line grouped by root cause. The first error seen for a root cause
+// is kept as the group's example. The zero value is ready to use and Add is safe
+// for concurrent use.
+// Example:
+//
+//	var agg logging.ErrorAggregator
+//	for _, item := range items { agg.Add(process(item)) }
+//	agg.Log(svc, "batch finished with errors")
+type ErrorAggregator struct {
+	mu     sync.Mutex
+	groups map[string]*errorGroup
+	order  []string
+	total  int
+}
+
+// errorGroup is the JSON shape of one entry in the error_groups field.
+type errorGroup struct {
+	Root         string   `json:"root"`
+	Count        int      `json:"count"`
+	ExampleChain []string `json:"example_chain"`
+}
+
+// Add records err under its root cause message. Nil errors are ignored.
+func (a *ErrorAggregator) Add(err error) {
+	if a == nil || err == nil {
+		return
+	}
+	chain, _, root, _ := buildErrorChain(err)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.groups == nil {
+		a.groups = make(map[string]*errorGroup)
+	}
+	a.total++
+	if g, ok := a.groups[root]; ok {
+		g.Count++
+		return
+	}
+	a.groups[root] = &errorGroup{Root: root, Count: 1, ExampleChain: chain}
+	a.order = append(a.order, root)
+}
+
+// Len returns the number of errors added so far.
+func (a *ErrorAggregator) Len() int {
+	if a == nil {
+		return 0
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.total
+}
+
+// Log writes one Error line with msg, error_count and an error_groups array of
+// {root, count, example_chain} in the order the root causes were first seen.
+// Nothing is written if no errors were added.
+func (a *ErrorAggregator) Log(s *Service, msg string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	if a.total == 0 {
+		a.mu.Unlock()
+		return
+	}
+	total := a.total
+	groups := make([]errorGroup, 0, len(a.order))
+	for _, root := range a.order {
+		groups = append(groups, *a.groups[root])
+	}
+	a.mu.Unlock()
+
+	s.ErrorWith().
+		Int("error_count", total).
+		Interface("error_groups", groups).
+		Msg(msg)
+}
@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorAggregator(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	errTimeout := errors.New("i/o timeout")
+	errRefused := errors.New("connection refused")
+
+	var agg ErrorAggregator
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			agg.Add(fmt.Errorf("item %d: %w", i, errTimeout))
+		}(i)
+	}
+	wg.Wait()
+	agg.Add(fmt.Errorf("item 9: %w", errRefused))
+	agg.Add(nil)
+	require.Equal(t, 4, agg.Len())
+
+	agg.Log(service, "batch failed")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "error", entry["level"])
+	assert.Equal(t, "batch failed", entry["message"])
+	assert.Equal(t, float64(4), entry["error_count"])
+
+	groups, ok := entry["error_groups"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, groups, 2)
+
+	first := groups[0].(map[string]interface{})
+	assert.Equal(t, "i/o timeout", first["root"])
+	assert.Equal(t, float64(3), first["count"])
+	chain := first["example_chain"].([]interface{})
+	require.Len(t, chain, 2)
+	assert.Equal(t, "i/o timeout", chain[1])
+
+	second := groups[1].(map[string]interface{})
+	assert.Equal(t, "connection refused", second["root"])
+	assert.Equal(t, float64(1), second["count"])
+	assert.Equal(t, []interface{}{"item 9: connection refused", "connection refused"}, second["example_chain"])
+}
+
+func TestErrorAggregator_Empty(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	var agg ErrorAggregator
+	agg.Log(service, "nothing to report")
+	service.InfoWith().Msg("marker")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "marker", entries[0]["message"])
+}
@@ -3,6 +3,7 @@ package logging
 import (
 	"bytes"
 	"encoding/json"
+	stderrs "errors"
 	"strings"
 	"testing"
 
@@ -86,3 +87,13 @@ func TestEventErr_EmitsChainFields(t *testing.T) {
 		_ = rootOp.(string)
 	}
 }
+
+func TestRootError(t *testing.T) {
+	inner := smerrors.New("db.Connect").Msg("connection refused")
+	outer := smerrors.New("server.Start").Err(inner).Msg("startup failed")
+	assert.Same(t, inner, rootError(outer))
+
+	plain := stderrs.New("plain")
+	assert.Same(t, plain, rootError(plain))
+	assert.Nil(t, rootError(nil))
+}
@@ -1,8 +1,14 @@
 package logging
 
 import (
+	"context"
+	"encoding/base64"
+	"fmt"
 	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
 	"net"
+	"net/url"
+	"slices"
 	"time"
 )
 
@@ -12,15 +18,28 @@ import (
 type LogContext interface {
 	Str(key, val string) LogContext
 	Strs(key string, vals []string) LogContext
+	// Stringer stores val.String() as a string field, evaluated once when the
+	// context is built; a nil val is stored as null.
+	Stringer(key string, val fmt.Stringer) LogContext
 	Int(key string, val int) LogContext
+	Int32(key string, val int32) LogContext
 	Int64(key string, val int64) LogContext
 	Uint(key string, val uint) LogContext
 	Uint64(key string, val uint64) LogContext
+	Float32(key string, val float32) LogContext
 	Float64(key string, val float64) LogContext
 	Bool(key string, val bool) LogContext
+	Bools(key string, vals []bool) LogContext
 	Time(key string, val time.Time) LogContext
+	Dur(key string, val time.Duration) LogContext
+	Bytes(key string, val []byte) LogContext
+	Hex(key string, val []byte) LogContext
 	Err(err error) LogContext
 	Interface(key string, val interface{}) LogContext
+	// Interfaces adds an arbitrary slice, serialized like Interface.
+	Interfaces(key string, vals []interface{}) LogContext
+	IPAddr(key string, val net.IP) LogContext
+	MACAddr(key string, val net.HardwareAddr) LogContext
 	// Logger creates and returns the new context logger
 	Logger() Logger
 }
@@ -29,9 +48,14 @@ type LogContext interface {
 // It wraps zerolog.Event to provide a clean API for adding typed fields to log entries.
 // Calling Msg/Msgf/Send finalizes the event. If the event is a trackedLogEvent, finalizing
 // the event also decrements the internal reference counters used for graceful shutdown.
+// The underlying zerolog.Event is recycled once finalized, so a LogEvent must not be
+// used after Msg/Msgf/Send; later calls are ignored.
 type LogEvent interface {
 	Str(key, val string) LogEvent
 	Strs(key string, vals []string) LogEvent
+	// Enum writes val like Str and, if val is not one of allowed, also sets
+	// <key>_invalid=true and logs a Debug line about the unexpected value.
+	Enum(key, val string, allowed []string) LogEvent
 	Stringer(key string, val interface{ String() string }) LogEvent
 	Int(key string, val int) LogEvent
 	Int8(key string, val int8) LogEvent
@@ -49,16 +73,34 @@ type LogEvent interface {
 	Bools(key string, vals []bool) LogEvent
 	Time(key string, val time.Time) LogEvent
 	Dur(key string, val time.Duration) LogEvent
-	// Err attaches an error and enriches the event with full chain fields
-	// (error_chain, error_root, error_history, error_ops, error_root_op).
+	// DurStr writes val in human units (time.Duration.String, e.g. "1.5s").
+	// Chain it with Dur under another key to also keep the numeric value.
+	DurStr(key string, val time.Duration) LogEvent
+	// Err attaches an error and enriches the event with chain fields
+	// (error_chain, error_root, error_history, error_ops, error_root_op)
+	// as selected by Config.ErrorEnrichment.
 	Err(err error) LogEvent
+	// ErrNoChain attaches an error as the bare error field without chain enrichment.
+	ErrNoChain(err error) LogEvent
 	// AnErr attaches a named error and enriches the event with prefixed chain fields.
 	AnErr(key string, err error) LogEvent
 	Bytes(key string, val []byte) LogEvent
 	Hex(key string, val []byte) LogEvent
+	// Base64 writes val as a standard base64 string, for binary payloads that
+	// would not survive as raw bytes, and its length in bytes as <key>_len.
+	Base64(key string, val []byte) LogEvent
 	IPAddr(key string, val net.IP) LogEvent
 	MACAddr(key string, val net.HardwareAddr) LogEvent
+	// URL writes u as a string with any userinfo password replaced by "xxxxx".
+	// A nil u is skipped.
+	URL(key string, u *url.URL) LogEvent
 	Interface(key string, val interface{}) LogEvent
+	// Proto writes a protobuf message as a nested JSON object using the
+	// marshaler set with SetProtoMarshaler. Without one, encoding/json is used,
+	// not protojson: fields follow the json tags of the generated code, so enums
+	// are written as numbers and well-known types (Timestamp, Any, wrappers)
+	// as their raw struct fields. A nil msg is skipped.
+	Proto(key string, msg ProtoMessage) LogEvent
 	Dict(key string, dict func(LogEvent)) LogEvent
 	// Msg writes the event with a literal message
 	Msg(msg string)
@@ -72,22 +114,32 @@ type LogEvent interface {
 // It is safe to call methods on a nil underlying event; in that case the methods
 // become no-ops. This allows returning a LogEvent even when the logger is disabled.
 type logEvent struct {
-	event *zerolog.Event
+	event   *zerolog.Event
+	service *Service // Owning service, used for per-service settings; may be nil
+	wrapper LogEvent // Outer event returned by fluent methods (the trackedLogEvent); may be nil
+	errMsg  string   // Text of the error passed to Err, used by first-per-message sampling
+	err     error    // First error passed to Err while OnFirstError is pending
 }
 
 // trackedLogEvent wraps a logEvent and decrements the active operations counter when finalized.
 // This ensures Close() can wait for in-flight logging to complete (up to a timeout) without races.
 type trackedLogEvent struct {
 	logEvent
-	service  *Service
-	location string // Debug: Track where this operation was created
+	level    zerolog.Level // Level the event was created at (NoLevel if unknown)
+	location string        // Debug: Track where this operation was created
+	finished atomic.Bool   // Set by the first Msg/Msgf/Send; later calls are no-ops
+	claim    func() bool   // Optional last check before writing; false drops the line (see Once)
 }
 
+// noopEvent is the shared no-op LogEvent. It holds no state, so every disabled
+// or rejected event can return it without allocating.
+var noopEvent = &logEvent{}
+
 // newLogEvent creates a new LogEvent wrapper.
 // If e is nil, the returned LogEvent is a no-op implementation.
 func newLogEvent(e *zerolog.Event) LogEvent {
 	if e == nil {
-		return &logEvent{event: nil}
+		return noopEvent
 	}
 	return &logEvent{event: e}
 }
@@ -95,30 +147,31 @@ func newLogEvent(e *zerolog.Event) LogEvent {
 // newTrackedLogEvent creates a new tracked LogEvent that decrements activeOps when finished
 // (on Msg/Msgf/Send calls).
 func newTrackedLogEvent(e *zerolog.Event, s *Service, location string) LogEvent {
+	return newTrackedLevelLogEvent(e, s, zerolog.NoLevel, location)
+}
+
+// newTrackedLevelLogEvent is newTrackedLogEvent for an event created at a known level.
+func newTrackedLevelLogEvent(e *zerolog.Event, s *Service, level zerolog.Level, location string) LogEvent {
 	if e == nil || s == nil {
 		// If event is nil, we need to decrement the counter that was already incremented
 		// by the caller (logEventBuilder or newTrackedContextLogEvent)
 		if s != nil {
-			s.activeOps.Add(-1)
-			s.wg.Done()
-			if location != "" {
-				s.mu.Lock()
-				if s.activeOpLocations != nil {
-					s.activeOpLocations[location]--
-					if s.activeOpLocations[location] <= 0 {
-						delete(s.activeOpLocations, location)
-					}
-				}
-				s.mu.Unlock()
-			}
+			s.releaseOp(location)
 		}
-		return &logEvent{event: nil}
+		return noopEvent
 	}
-	return &trackedLogEvent{
-		logEvent: logEvent{event: e},
-		service:  s,
+	// Wrappers are not pooled. Callers may keep the LogEvent after Msg, and that
+	// reference is the wrapper itself, so a recycled wrapper cannot tell a stale
+	// Msg from its new owner's; a generation check would need a separately
+	// allocated handle, costing the allocation pooling was meant to save.
+	// Disabled events still share noopEvent and do not allocate.
+	t := &trackedLogEvent{
+		logEvent: logEvent{event: e, service: s},
+		level:    level,
 		location: location,
 	}
+	t.wrapper = t
+	return t
 }
 
 // newTrackedContextLogEvent creates a tracked log event for context loggers
@@ -128,21 +181,29 @@ func newTrackedContextLogEvent(cl *contextLogger, level zerolog.Level) LogEvent
 		return newLogEvent(nil)
 	}
 
+	// Disabled levels are rejected before taking the lock or touching the counters;
+	// the context logger's level is fixed when it is created, apart from SetQuiet
+	if cl.logger.GetLevel() > level || cl.parent.quietLevel(level) != level {
+		return newLogEvent(nil)
+	}
+
 	// Acquire read lock to prevent Close() from running
 	cl.parent.mu.RLock()
-	defer cl.parent.mu.RUnlock()
 
 	if !cl.parent.isInitialized.Load() {
-		return newLogEvent(nil)
-	}
-
-	if cl.logger.GetLevel() > level {
+		cl.parent.mu.RUnlock()
 		return newLogEvent(nil)
 	}
 
 	// Increment active operations counter ONLY if a log event will be created
-	cl.parent.activeOps.Add(1)
-	cl.parent.wg.Add(1)
+	cl.parent.trackOp()
+
+	if level == zerolog.PanicLevel {
+		// zerolog may panic while creating the event, so the shutdown lock is released first
+		cl.parent.mu.RUnlock()
+		return cl.withContextFields(cl.parent.panicEvent(cl.logger, emptyString), level)
+	}
+	defer cl.parent.mu.RUnlock()
 
 	var event *zerolog.Event
 	switch level {
@@ -155,227 +216,438 @@ func newTrackedContextLogEvent(cl *contextLogger, level zerolog.Level) LogEvent
 	case zerolog.ErrorLevel:
 		event = cl.logger.Error()
 	case zerolog.FatalLevel:
-		event = cl.logger.Fatal()
-	case zerolog.PanicLevel:
-		event = cl.logger.Panic()
+		// Exit is handled by the tracked event (see Service.fatalExit); fatal events are never sampled
+		unsampled := cl.logger.Sample(nil)
+		event = unsampled.WithLevel(zerolog.FatalLevel)
 	case zerolog.TraceLevel:
 		event = cl.logger.Trace()
 	default:
 		// Should not happen, but decrement counter if it does
-		cl.parent.activeOps.Add(-1)
-		cl.parent.wg.Done()
+		cl.parent.releaseOp(emptyString)
 		return newLogEvent(nil)
 	}
 
-	return newTrackedLogEvent(event, cl.parent, "")
+	return cl.withContextFields(event, level)
+}
+
+// withContextFields adds the fields taken from the logger's context.Context and
+// wraps event for shutdown tracking.
+func (cl *contextLogger) withContextFields(event *zerolog.Event, level zerolog.Level) LogEvent {
+	if cl.ctx != nil {
+		event = appendCtxFields(event, cl.ctx)
+		if cl.margin >= 0 {
+			event = appendDeadlineFields(event, cl.ctx, cl.margin)
+		}
+	}
+
+	return newTrackedLevelLogEvent(event, cl.parent, level, "")
+}
+
+// chain returns the LogEvent handed back by the fluent field methods. For tracked
+// events this is the wrapping trackedLogEvent, so that a chained Msg/Msgf/Send
+// still releases the shutdown tracking.
+func (e *logEvent) chain() LogEvent {
+	if e.wrapper != nil {
+		return e.wrapper
+	}
+	return e
 }
 
 func (e *logEvent) Str(key, val string) LogEvent {
 	if e.event != nil {
-		e.event.Str(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Str(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Strs(key string, vals []string) LogEvent {
 	if e.event != nil {
-		e.event.Strs(key, vals)
+		if key, vals, ok := transformAttr(e, key, vals); ok {
+			e.event.Strs(key, vals)
+		}
 	}
-	return e
+	return e.chain()
+}
+
+func (e *logEvent) Enum(key, val string, allowed []string) LogEvent {
+	if e.event == nil {
+		return e.chain()
+	}
+	key, val, ok := transformAttr(e, key, val)
+	if !ok {
+		return e.chain()
+	}
+	e.event.Str(key, val)
+	for _, a := range allowed {
+		if a == val {
+			return e.chain()
+		}
+	}
+	e.event.Bool(key+"_invalid", true)
+	if e.service != nil {
+		e.service.DebugWith().
+			Str("enum_key", key).
+			Str("enum_value", val).
+			Strs("enum_allowed", allowed).
+			Msg("enum value not in allowed set")
+	}
+	return e.chain()
 }
 
 func (e *logEvent) Stringer(key string, val interface{ String() string }) LogEvent {
 	if e.event != nil {
-		e.event.Stringer(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Stringer(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Int(key string, val int) LogEvent {
 	if e.event != nil {
-		e.event.Int(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Int(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Int8(key string, val int8) LogEvent {
 	if e.event != nil {
-		e.event.Int8(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Int8(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Int16(key string, val int16) LogEvent {
 	if e.event != nil {
-		e.event.Int16(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Int16(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Int32(key string, val int32) LogEvent {
 	if e.event != nil {
-		e.event.Int32(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Int32(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Int64(key string, val int64) LogEvent {
 	if e.event != nil {
-		e.event.Int64(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Int64(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Uint(key string, val uint) LogEvent {
 	if e.event != nil {
-		e.event.Uint(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Uint(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Uint8(key string, val uint8) LogEvent {
 	if e.event != nil {
-		e.event.Uint8(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Uint8(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Uint16(key string, val uint16) LogEvent {
 	if e.event != nil {
-		e.event.Uint16(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Uint16(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Uint32(key string, val uint32) LogEvent {
 	if e.event != nil {
-		e.event.Uint32(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Uint32(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Uint64(key string, val uint64) LogEvent {
 	if e.event != nil {
-		e.event.Uint64(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Uint64(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Float32(key string, val float32) LogEvent {
 	if e.event != nil {
-		e.event.Float32(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Float32(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Float64(key string, val float64) LogEvent {
 	if e.event != nil {
-		e.event.Float64(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Float64(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Bool(key string, val bool) LogEvent {
 	if e.event != nil {
-		e.event.Bool(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Bool(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Bools(key string, vals []bool) LogEvent {
 	if e.event != nil {
-		e.event.Bools(key, vals)
+		if key, vals, ok := transformAttr(e, key, vals); ok {
+			e.event.Bools(key, vals)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Time(key string, val time.Time) LogEvent {
 	if e.event != nil {
-		e.event.Time(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Time(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Dur(key string, val time.Duration) LogEvent {
 	if e.event != nil {
-		e.event.Dur(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Dur(key, val)
+		}
+	}
+	return e.chain()
+}
+
+func (e *logEvent) DurStr(key string, val time.Duration) LogEvent {
+	if e.event != nil {
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Str(key, val.String())
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Err(err error) LogEvent {
 	if e.event != nil {
 		e.event.Err(err)
 		if err != nil {
-			chain, ops, root, rootOp := buildErrorChain(err)
-			if len(chain) > 0 {
-				// include array and joined string for readability
-				e.event.Strs("error_chain", chain)
-				e.event.Str("error_root", root)
-				e.event.Str("error_history", joinChain(chain))
-				// include ops if any present
-				e.event.Strs("error_ops", ops)
-				if rootOp != "" {
-					e.event.Str("error_root_op", rootOp)
-				}
+			if e.service != nil && e.service.msgSampler != nil {
+				e.errMsg = err.Error()
+			}
+			e.enrichError(errorChainFieldKeys, err)
+			e.attachBreadcrumbs()
+			if e.err == nil && e.service != nil && e.service.firstErrorPending() {
+				e.err = err
 			}
 		}
 	}
-	return e
+	return e.chain()
+}
+
+func (e *logEvent) ErrNoChain(err error) LogEvent {
+	if e.event != nil {
+		e.event.Err(err)
+	}
+	return e.chain()
 }
 
 func (e *logEvent) AnErr(key string, err error) LogEvent {
 	if e.event != nil {
 		e.event.AnErr(key, err)
 		if err != nil {
-			chain, ops, root, rootOp := buildErrorChain(err)
-			if len(chain) > 0 {
-				e.event.Strs(key+"_chain", chain)
-				e.event.Str(key+"_root", root)
-				e.event.Str(key+"_history", joinChain(chain))
-				e.event.Strs(key+"_ops", ops)
-				if rootOp != "" {
-					e.event.Str(key+"_root_op", rootOp)
-				}
+			e.enrichError(newErrorChainKeys(key), err)
+		}
+	}
+	return e.chain()
+}
+
+// errorChainKeys names the fields emitted by error chain enrichment.
+type errorChainKeys struct {
+	chain, root, history, ops, rootOp string
+	typ, rootType                     string // Only with Config.ErrorIncludeType
+}
+
+// errorChainFieldKeys are the field names used by Err.
+var errorChainFieldKeys = newErrorChainKeys("error")
+
+// newErrorChainKeys returns the enrichment field names for the given prefix.
+func newErrorChainKeys(prefix string) errorChainKeys {
+	return errorChainKeys{
+		chain:    prefix + "_chain",
+		root:     prefix + "_root",
+		history:  prefix + "_history",
+		ops:      prefix + "_ops",
+		rootOp:   prefix + "_root_op",
+		typ:      prefix + "_type",
+		rootType: prefix + "_root_type",
+	}
+}
+
+// enrichError adds the error chain fields for err according to the owning
+// service's enrichment mode. Events without a service use full enrichment.
+func (e *logEvent) enrichError(keys errorChainKeys, err error) {
+	e.service.enrichErrorFields(eventFields{e.event}, keys, err)
+}
+
+// errorFieldSink receives the string fields written by error chain enrichment,
+// so that events and contexts share one implementation.
+type errorFieldSink interface {
+	Str(key, val string)
+	Strs(key string, vals []string)
+}
+
+type eventFields struct{ event *zerolog.Event }
+
+func (f eventFields) Str(key, val string)            { f.event.Str(key, val) }
+func (f eventFields) Strs(key string, vals []string) { f.event.Strs(key, vals) }
+
+type contextFields struct{ context *zerolog.Context }
+
+func (f contextFields) Str(key, val string)            { *f.context = f.context.Str(key, val) }
+func (f contextFields) Strs(key string, vals []string) { *f.context = f.context.Strs(key, vals) }
+
+// enrichErrorFields writes the error chain fields for err to sink according to
+// the service's enrichment mode. A nil service uses full enrichment.
+func (s *Service) enrichErrorFields(sink errorFieldSink, keys errorChainKeys, err error) {
+	mode := enrichmentFull
+	var allowOps []string
+	var historySep string
+	var historyMax int
+	if s != nil {
+		mode = s.enrichment
+		allowOps = s.opsAllowPrefix
+		historySep = s.Config.ErrorHistorySeparator
+		historyMax = s.Config.ErrorHistoryMaxLinks
+		if s.Config.ErrorIncludeType {
+			sink.Str(keys.typ, fmt.Sprintf("%T", err))
+			if root := rootError(err); root != err {
+				sink.Str(keys.rootType, fmt.Sprintf("%T", root))
 			}
 		}
 	}
-	return e
+	if mode == enrichmentOff {
+		return
+	}
+
+	chain, ops, root, rootOp := buildErrorChain(err)
+	if len(chain) == 0 {
+		return
+	}
+	if len(allowOps) > 0 {
+		ops = filterOps(ops, allowOps)
+		if !hasAnyPrefix(rootOp, allowOps) {
+			rootOp = emptyString
+		}
+	}
+	full := mode == enrichmentFull
+	if full {
+		// include array and joined string for readability
+		sink.Strs(keys.chain, chain)
+	}
+	sink.Str(keys.root, root)
+	if full {
+		sink.Str(keys.history, joinChain(chain, historySep, historyMax))
+		// include ops if any present
+		sink.Strs(keys.ops, ops)
+	}
+	if rootOp != "" {
+		sink.Str(keys.rootOp, rootOp)
+	}
 }
 
 func (e *logEvent) Bytes(key string, val []byte) LogEvent {
 	if e.event != nil {
-		e.event.Bytes(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Bytes(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Hex(key string, val []byte) LogEvent {
 	if e.event != nil {
-		e.event.Hex(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Hex(key, val)
+		}
 	}
-	return e
+	return e.chain()
+}
+
+func (e *logEvent) Base64(key string, val []byte) LogEvent {
+	if e.event != nil {
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.Str(key, base64.StdEncoding.EncodeToString(val)).
+				Int(key+base64LenSuffix, len(val))
+		}
+	}
+	return e.chain()
+}
+
+func (e *logEvent) URL(key string, u *url.URL) LogEvent {
+	if e.event != nil && u != nil {
+		if key, u, ok := transformAttr(e, key, u); ok && u != nil {
+			e.event.Str(key, u.Redacted())
+		}
+	}
+	return e.chain()
 }
 
 func (e *logEvent) IPAddr(key string, val net.IP) LogEvent {
 	if e.event != nil {
-		e.event.IPAddr(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.IPAddr(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) MACAddr(key string, val net.HardwareAddr) LogEvent {
 	if e.event != nil {
-		e.event.MACAddr(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.event.MACAddr(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Interface(key string, val interface{}) LogEvent {
 	if e.event != nil {
-		e.event.Interface(key, val)
+		if key, val, ok := transformAttr(e, key, val); ok {
+			e.interfaceField(key, val)
+		}
 	}
-	return e
+	return e.chain()
 }
 
 // Dict for nested objects
@@ -385,7 +657,7 @@ func (e *logEvent) Dict(key string, dict func(LogEvent)) LogEvent {
 		dict(newLogEvent(dictEvent))
 		e.event.Dict(key, dictEvent)
 	}
-	return e
+	return e.chain()
 }
 
 func (e *logEvent) Msg(msg string) {
@@ -408,72 +680,153 @@ func (e *logEvent) Send() {
 
 // Override Msg, Msgf, and Send for trackedLogEvent to decrement counter
 func (e *trackedLogEvent) Msg(msg string) {
-	defer func() {
-		e.service.activeOps.Add(-1)
-		e.service.wg.Done()
-		// Also decrement location counter if tracking is enabled
-		if e.location != "" {
-			e.service.mu.Lock()
-			if e.service.activeOpLocations != nil {
-				e.service.activeOpLocations[e.location]--
-				if e.service.activeOpLocations[e.location] <= 0 {
-					delete(e.service.activeOpLocations, e.location)
-				}
-			}
-			e.service.mu.Unlock()
-		}
-	}()
+	if !e.finished.CompareAndSwap(false, true) {
+		// Already finalized: the counters were released by the first call
+		return
+	}
+	if e.level == zerolog.FatalLevel {
+		// Runs after release, once the line has been written
+		defer e.service.fatalExit()
+	}
+	defer e.release()
 	if e.event != nil {
+		if e.filtered(msg) || e.sampledOut(msg) || !e.claimed() {
+			e.event.Discard()
+			return
+		}
 		e.event.Msg(msg)
+		e.notifyFirstError(msg)
 	}
 }
 
 func (e *trackedLogEvent) Msgf(format string, v ...interface{}) {
-	defer func() {
-		e.service.activeOps.Add(-1)
-		e.service.wg.Done()
-		// Also decrement location counter if tracking is enabled
-		if e.location != "" {
-			e.service.mu.Lock()
-			if e.service.activeOpLocations != nil {
-				e.service.activeOpLocations[e.location]--
-				if e.service.activeOpLocations[e.location] <= 0 {
-					delete(e.service.activeOpLocations, e.location)
-				}
+	if !e.finished.CompareAndSwap(false, true) {
+		// Already finalized: the counters were released by the first call
+		return
+	}
+	if e.level == zerolog.FatalLevel {
+		// Runs after release, once the line has been written
+		defer e.service.fatalExit()
+	}
+	defer e.release()
+	if e.event != nil {
+		if e.service.filter.Load() != nil || e.service.msgSampler != nil || e.err != nil {
+			// Format once so the filter, sampler and first error callback see the final message
+			msg := fmt.Sprintf(format, v...)
+			if e.filtered(msg) || e.sampledOut(msg) || !e.claimed() {
+				e.event.Discard()
+				return
 			}
-			e.service.mu.Unlock()
+			e.event.Msg(msg)
+			e.notifyFirstError(msg)
+			return
+		}
+		if !e.claimed() {
+			e.event.Discard()
+			return
 		}
-	}()
-	if e.event != nil {
 		e.event.Msgf(format, v...)
 	}
 }
 
 func (e *trackedLogEvent) Send() {
-	defer func() {
-		e.service.activeOps.Add(-1)
-		e.service.wg.Done()
-		// Also decrement location counter if tracking is enabled
-		if e.location != "" {
-			e.service.mu.Lock()
-			if e.service.activeOpLocations != nil {
-				e.service.activeOpLocations[e.location]--
-				if e.service.activeOpLocations[e.location] <= 0 {
-					delete(e.service.activeOpLocations, e.location)
-				}
-			}
-			e.service.mu.Unlock()
-		}
-	}()
+	if !e.finished.CompareAndSwap(false, true) {
+		// Already finalized: the counters were released by the first call
+		return
+	}
+	if e.level == zerolog.FatalLevel {
+		// Runs after release, once the line has been written
+		defer e.service.fatalExit()
+	}
+	defer e.release()
 	if e.event != nil {
+		if e.filtered("") || e.sampledOut("") || !e.claimed() {
+			e.event.Discard()
+			return
+		}
 		e.event.Send()
+		e.notifyFirstError(emptyString)
+	}
+}
+
+// release releases the operation (see Service.releaseOp), then clears the
+// wrapper. The wrapper must not be used afterwards; finished keeps later
+// Msg/Msgf/Send calls from releasing again.
+func (e *trackedLogEvent) release() {
+	e.service.releaseOp(e.location)
+
+	// Nil the event so a stale reference cannot write into a recycled zerolog event
+	e.event = nil
+	e.service = nil
+	e.wrapper = nil
+	e.errMsg = emptyString
+	e.err = nil
+	e.location = emptyString
+	e.claim = nil
+}
+
+// claimed runs the claim, if any, once nothing else keeps the line from being
+// written.
+func (e *trackedLogEvent) claimed() bool {
+	return e.claim == nil || e.claim()
+}
+
+// maxSeenMessages bounds the set of error messages remembered by
+// first-per-message sampling; once full, new messages are sampled like repeats.
+const maxSeenMessages = 10000
+
+// sampledOut reports whether first-per-message sampling drops this event. The
+// first occurrence of each error-level message (with its error text) is always
+// kept; other events go through the burst sampler. Fatal, panic and audit
+// events are never sampled.
+func (e *trackedLogEvent) sampledOut(msg string) bool {
+	s := e.service
+	if s.msgSampler == nil {
+		return false
+	}
+	switch e.level {
+	case zerolog.FatalLevel, zerolog.PanicLevel, zerolog.NoLevel:
+		return false
+	case zerolog.ErrorLevel:
+		if s.seenCount.Load() < maxSeenMessages && s.seenMessages.add(msg+"\x00"+e.errMsg) {
+			s.seenCount.Add(1)
+			return false
+		}
+	}
+	return !s.msgSampler.Sample(e.level)
+}
+
+// filtered reports whether the service filter rejects this event. Fatal and
+// panic events are never filtered so that their exit/panic semantics are kept.
+func (e *trackedLogEvent) filtered(msg string) bool {
+	fn := e.service.filter.Load()
+	if fn == nil || e.level == zerolog.FatalLevel || e.level == zerolog.PanicLevel {
+		return false
 	}
+	return !(*fn)(e.level, msg)
 }
 
 // logContext implements LogContext by wrapping zerolog.Context
 type logContext struct {
 	context zerolog.Context
 	service *Service
+	ctx     context.Context // inherited from a CtxLogger, may be nil
+	margin  time.Duration   // inherited from WithDeadline, see noDeadlineFields
+	fields  []contextField  // fields added so far, including inherited ones; see Merge
+}
+
+// contextField is a field added to a logContext, kept so that Merge can apply
+// it to another context (zerolog cannot read context fields back).
+type contextField struct {
+	key   string
+	apply func(zerolog.Context) zerolog.Context
+}
+
+// add applies fn to the context and records it under key.
+func (c *logContext) add(key string, fn func(zerolog.Context) zerolog.Context) LogContext {
+	c.context = fn(c.context)
+	c.fields = append(c.fields, contextField{key: key, apply: fn})
+	return c
 }
 
 // contextLogger wraps a zerolog.Logger created from a context
@@ -482,6 +835,9 @@ type logContext struct {
 type contextLogger struct {
 	logger *zerolog.Logger
 	parent *Service
+	ctx    context.Context // set by CtxLogger; inspected on each event
+	margin time.Duration   // set by WithDeadline; deadline_near threshold, see noDeadlineFields
+	fields []contextField  // fields of the logger's context, see Merge
 }
 
 func (cl *contextLogger) TraceWith() LogEvent {
@@ -550,62 +906,107 @@ func (cl *contextLogger) With() LogContext {
 	return &logContext{
 		context: cl.logger.With(),
 		service: cl.parent,
+		ctx:     cl.ctx,
+		margin:  cl.margin,
+		fields:  slices.Clip(cl.fields),
 	}
 }
 
 func (c *logContext) Str(key, val string) LogContext {
-	c.context = c.context.Str(key, val)
-	return c
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Str(key, val) })
 }
 
 func (c *logContext) Strs(key string, vals []string) LogContext {
-	c.context = c.context.Strs(key, vals)
-	return c
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Strs(key, vals) })
+}
+
+func (c *logContext) Stringer(key string, val fmt.Stringer) LogContext {
+	if val == nil {
+		return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Interface(key, nil) })
+	}
+	str := val.String()
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Str(key, str) })
 }
 
 func (c *logContext) Int(key string, val int) LogContext {
-	c.context = c.context.Int(key, val)
-	return c
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Int(key, val) })
+}
+
+func (c *logContext) Int32(key string, val int32) LogContext {
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Int32(key, val) })
 }
 
 func (c *logContext) Int64(key string, val int64) LogContext {
-	c.context = c.context.Int64(key, val)
-	return c
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Int64(key, val) })
 }
 
 func (c *logContext) Uint(key string, val uint) LogContext {
-	c.context = c.context.Uint(key, val)
-	return c
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Uint(key, val) })
 }
 
 func (c *logContext) Uint64(key string, val uint64) LogContext {
-	c.context = c.context.Uint64(key, val)
-	return c
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Uint64(key, val) })
+}
+
+func (c *logContext) Float32(key string, val float32) LogContext {
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Float32(key, val) })
 }
 
 func (c *logContext) Float64(key string, val float64) LogContext {
-	c.context = c.context.Float64(key, val)
-	return c
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Float64(key, val) })
 }
 
 func (c *logContext) Bool(key string, val bool) LogContext {
-	c.context = c.context.Bool(key, val)
-	return c
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Bool(key, val) })
+}
+
+func (c *logContext) Bools(key string, vals []bool) LogContext {
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Bools(key, vals) })
 }
 
 func (c *logContext) Time(key string, val time.Time) LogContext {
-	c.context = c.context.Time(key, val)
-	return c
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Time(key, val) })
+}
+
+func (c *logContext) Dur(key string, val time.Duration) LogContext {
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Dur(key, val) })
 }
 
+func (c *logContext) Bytes(key string, val []byte) LogContext {
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Bytes(key, val) })
+}
+
+func (c *logContext) Hex(key string, val []byte) LogContext {
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Hex(key, val) })
+}
+
+// Err adds err to every line of the context logger, enriched with the chain
+// fields selected by the parent service's Config.ErrorEnrichment.
 func (c *logContext) Err(err error) LogContext {
-	c.context = c.context.Err(err)
-	return c
+	service := c.service
+	return c.add(zerolog.ErrorFieldName, func(zc zerolog.Context) zerolog.Context {
+		zc = zc.Err(err)
+		if err != nil {
+			service.enrichErrorFields(contextFields{&zc}, errorChainFieldKeys, err)
+		}
+		return zc
+	})
 }
 
 func (c *logContext) Interface(key string, val interface{}) LogContext {
-	c.context = c.context.Interface(key, val)
-	return c
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Interface(key, val) })
+}
+
+func (c *logContext) Interfaces(key string, vals []interface{}) LogContext {
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.Interface(key, vals) })
+}
+
+func (c *logContext) IPAddr(key string, val net.IP) LogContext {
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.IPAddr(key, val) })
+}
+
+func (c *logContext) MACAddr(key string, val net.HardwareAddr) LogContext {
+	return c.add(key, func(zc zerolog.Context) zerolog.Context { return zc.MACAddr(key, val) })
 }
 
 func (c *logContext) Logger() Logger {
@@ -615,6 +1016,10 @@ func (c *logContext) Logger() Logger {
 	newService := &contextLogger{
 		logger: &logger,
 		parent: c.service,
+		ctx:    c.ctx,
+		margin: c.margin,
+		// Clipped so that fields added to c later do not leak into this logger
+		fields: slices.Clip(c.fields),
 	}
 	return newService
 }
@@ -622,19 +1027,33 @@ func (c *logContext) Logger() Logger {
 // noopLogContext is a no-op implementation of LogContext
 type noopLogContext struct{}
 
-func (n *noopLogContext) Str(key, val string) LogContext             { return n }
-func (n *noopLogContext) Strs(key string, vals []string) LogContext  { return n }
-func (n *noopLogContext) Int(key string, val int) LogContext         { return n }
-func (n *noopLogContext) Int64(key string, val int64) LogContext     { return n }
-func (n *noopLogContext) Uint(key string, val uint) LogContext       { return n }
-func (n *noopLogContext) Uint64(key string, val uint64) LogContext   { return n }
-func (n *noopLogContext) Float64(key string, val float64) LogContext { return n }
-func (n *noopLogContext) Bool(key string, val bool) LogContext       { return n }
-func (n *noopLogContext) Time(key string, val time.Time) LogContext  { return n }
-func (n *noopLogContext) Err(err error) LogContext                   { return n }
+func (n *noopLogContext) Str(key, val string) LogContext                   { return n }
+func (n *noopLogContext) Strs(key string, vals []string) LogContext        { return n }
+func (n *noopLogContext) Stringer(key string, val fmt.Stringer) LogContext { return n }
+func (n *noopLogContext) Int(key string, val int) LogContext               { return n }
+func (n *noopLogContext) Int32(key string, val int32) LogContext           { return n }
+func (n *noopLogContext) Int64(key string, val int64) LogContext           { return n }
+func (n *noopLogContext) Uint(key string, val uint) LogContext             { return n }
+func (n *noopLogContext) Uint64(key string, val uint64) LogContext         { return n }
+func (n *noopLogContext) Float32(key string, val float32) LogContext       { return n }
+func (n *noopLogContext) Float64(key string, val float64) LogContext       { return n }
+func (n *noopLogContext) Bool(key string, val bool) LogContext             { return n }
+func (n *noopLogContext) Bools(key string, vals []bool) LogContext         { return n }
+func (n *noopLogContext) Time(key string, val time.Time) LogContext        { return n }
+func (n *noopLogContext) Dur(key string, val time.Duration) LogContext     { return n }
+func (n *noopLogContext) Bytes(key string, val []byte) LogContext          { return n }
+func (n *noopLogContext) Hex(key string, val []byte) LogContext            { return n }
+func (n *noopLogContext) Err(err error) LogContext                         { return n }
 func (n *noopLogContext) Interface(key string, val interface{}) LogContext {
 	return n
 }
+func (n *noopLogContext) Interfaces(key string, vals []interface{}) LogContext {
+	return n
+}
+func (n *noopLogContext) IPAddr(key string, val net.IP) LogContext { return n }
+func (n *noopLogContext) MACAddr(key string, val net.HardwareAddr) LogContext {
+	return n
+}
 func (n *noopLogContext) Logger() Logger { return &noopLogger{} }
 
 // noopLogger is a no-op implementation of Logger
@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// EventBuilder accumulates fields that can be emitted several times, at
+// different levels, without re-specifying them. Each terminal method (Debug,
+// Info, Warn, Error) creates a fresh LogEvent carrying the accumulated fields;
+// finish it with Msg/Msgf/Send as usual. An EventBuilder is not safe for
+// concurrent use while fields are being added.
+// Example:
+//
+//	b := svc.Event().Str("batch_id", id).Int("items", n)
+//	b.Info().Msg("batch done")
+//	b.Debug().Strs("skipped", skipped).Msg("batch details")
+type EventBuilder struct {
+	service *Service
+	fields  []func(LogEvent)
+}
+
+// Event returns an empty EventBuilder for this service.
+func (s *Service) Event() *EventBuilder {
+	return &EventBuilder{service: s}
+}
+
+func (b *EventBuilder) add(fn func(LogEvent)) *EventBuilder {
+	b.fields = append(b.fields, fn)
+	return b
+}
+
+func (b *EventBuilder) Str(key, val string) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Str(key, val) })
+}
+
+func (b *EventBuilder) Strs(key string, vals []string) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Strs(key, vals) })
+}
+
+func (b *EventBuilder) Int(key string, val int) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Int(key, val) })
+}
+
+func (b *EventBuilder) Int64(key string, val int64) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Int64(key, val) })
+}
+
+func (b *EventBuilder) Uint64(key string, val uint64) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Uint64(key, val) })
+}
+
+func (b *EventBuilder) Float64(key string, val float64) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Float64(key, val) })
+}
+
+func (b *EventBuilder) Bool(key string, val bool) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Bool(key, val) })
+}
+
+func (b *EventBuilder) Time(key string, val time.Time) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Time(key, val) })
+}
+
+func (b *EventBuilder) Dur(key string, val time.Duration) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Dur(key, val) })
+}
+
+func (b *EventBuilder) Err(err error) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Err(err) })
+}
+
+func (b *EventBuilder) AnErr(key string, err error) *EventBuilder {
+	return b.add(func(e LogEvent) { e.AnErr(key, err) })
+}
+
+func (b *EventBuilder) Interface(key string, val interface{}) *EventBuilder {
+	return b.add(func(e LogEvent) { e.Interface(key, val) })
+}
+
+// Debug returns a new Debug-level LogEvent carrying the accumulated fields.
+func (b *EventBuilder) Debug() LogEvent {
+	return b.materialize(logEventBuilder(b.service, zerolog.DebugLevel))
+}
+
+// Info returns a new Info-level LogEvent carrying the accumulated fields.
+func (b *EventBuilder) Info() LogEvent {
+	return b.materialize(logEventBuilder(b.service, zerolog.InfoLevel))
+}
+
+// Warn returns a new Warn-level LogEvent carrying the accumulated fields.
+func (b *EventBuilder) Warn() LogEvent {
+	return b.materialize(logEventBuilder(b.service, zerolog.WarnLevel))
+}
+
+// Error returns a new Error-level LogEvent carrying the accumulated fields.
+func (b *EventBuilder) Error() LogEvent {
+	return b.materialize(logEventBuilder(b.service, zerolog.ErrorLevel))
+}
+
+// materialize applies the accumulated fields to e. Disabled events are returned
+// untouched.
+func (b *EventBuilder) materialize(e LogEvent) LogEvent {
+	if e == noopEvent {
+		return e
+	}
+	for _, fn := range b.fields {
+		fn(e)
+	}
+	return e
+}
@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBuilder(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.Level = "info"
+	service, dir := newFileTestService(t, cfg, Config{})
+
+	b := service.Event().Str("batch_id", "b1").Int("items", 3).Bool("partial", false)
+	b.Info().Msg("batch done")
+	b.Debug().Msg("filtered by level")
+	b.Str("stage", "upload").Warn().Int("retries", 2).Msg("batch slow")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, "b1", entry["batch_id"])
+		assert.Equal(t, float64(3), entry["items"])
+		assert.Equal(t, false, entry["partial"])
+	}
+	assert.Equal(t, "info", entries[0]["level"])
+	assert.NotContains(t, entries[0], "stage")
+	assert.Equal(t, "warn", entries[1]["level"])
+	assert.Equal(t, "upload", entries[1]["stage"])
+	assert.Equal(t, float64(2), entries[1]["retries"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestEventBuilder_Uninitialized(t *testing.T) {
+	var nilService *Service
+	b := nilService.Event().Str("k", "v")
+	b.Info().Msg("no panic")
+	b.Error().Msg("no panic")
+}
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// defaultFatalExitCode is used when Config.FatalExitCode is zero.
+const defaultFatalExitCode = 1
+
+// SetFatalHook registers a function run after a fatal line has been written and
+// before the process exits, for last-chance cleanup. A panic inside it is
+// recovered. Passing nil removes the hook.
+func (s *Service) SetFatalHook(fn func()) {
+	if s == nil {
+		return
+	}
+	if fn == nil {
+		s.fatalHook.Store(nil)
+		return
+	}
+	s.fatalHook.Store(&fn)
+}
+
+// fatalExit runs the fatal hook, flushes buffered file output and exits the
+// process with Config.FatalExitCode. It is called once a FatalWith event has
+// been written, and does nothing when Config.DisableExitOnFatal is set.
+func (s *Service) fatalExit() {
+	if s.Config.DisableExitOnFatal {
+		return
+	}
+	if fn := s.fatalHook.Load(); fn != nil {
+		func() {
+			defer func() {
+				// The process must still exit if the hook panics
+				_ = recover()
+			}()
+			(*fn)()
+		}()
+	}
+
+	s.mu.RLock()
+	bufWriter := s.bufWriter
+	s.mu.RUnlock()
+	if bufWriter != nil {
+		_ = bufWriter.Flush()
+	}
+
+	code := s.Config.FatalExitCode
+	if code == 0 {
+		code = defaultFatalExitCode
+	}
+	exit := s.exit
+	if exit == nil {
+		exit = os.Exit
+	}
+	exit(code)
+}
+
+// panicEvent starts a panic-level event on logger. Unless Config.DisableExitOnFatal
+// is set, zerolog panics once the event is written. Panic events are never
+// sampled: zerolog raises a sampled-out panic while creating the event, which
+// would skip the caller's release of the operation tracked for location. The
+// caller must not hold s.mu, since the panic may be recovered and Close must
+// still be able to take the lock.
+func (s *Service) panicEvent(logger *zerolog.Logger, location string) *zerolog.Event {
+	unsampled := logger.Sample(nil)
+	if s.Config.DisableExitOnFatal {
+		return unsampled.WithLevel(zerolog.PanicLevel)
+	}
+	// A disabled event (e.g. a raised zerolog.GlobalLevel) still panics right away
+	defer func() {
+		if r := recover(); r != nil {
+			s.releaseOp(location)
+			panic(r)
+		}
+	}()
+	return unsampled.Panic()
+}
@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFatalExitCodeAndHook(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.ConsoleLogging = false
+	cfg.FileLogging = true
+	service := &Service{
+		WorkingDir:    t.TempDir(),
+		ConfigService: newTestConfigService(cfg),
+		Config:        Config{FatalExitCode: 3, FlushIntervalMS: 60000, SampleBurst: 1, SamplePeriodMS: 60000},
+	}
+	var order []string
+	exitCode := -1
+	service.exit = func(code int) {
+		order = append(order, "exit")
+		exitCode = code
+	}
+	require.NoError(t, service.Initialize())
+	t.Cleanup(func() { _ = service.Close() })
+
+	service.SetFatalHook(func() { order = append(order, "hook") })
+
+	// Use up the sampler burst: fatal events must still be written and exit
+	service.InfoWith().Msg("burst")
+	service.FatalWith().Str("reason", "disk gone").Msg("cannot continue")
+
+	assert.Equal(t, 3, exitCode)
+	assert.Equal(t, []string{"hook", "exit"}, order)
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	// The buffered line was flushed before exiting
+	entries := readLogEntries(t, filepath.Join(service.WorkingDir, cfg.RelLogFileDir), logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "fatal", entries[1]["level"])
+	assert.Equal(t, "disk gone", entries[1]["reason"])
+}
+
+func TestFatalExit_DefaultCodeAndPanickingHook(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+	exitCode := -1
+	service.exit = func(code int) { exitCode = code }
+	service.SetFatalHook(func() { panic("cleanup failed") })
+
+	service.With().Str("component", "cat").Logger().FatalWith().Msgf("fatal %s", "from child")
+	assert.Equal(t, defaultFatalExitCode, exitCode)
+}
+
+func TestDisableExitOnFatal(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{DisableExitOnFatal: true})
+	// No exit override: os.Exit would end the test binary
+	hookRan := false
+	service.SetFatalHook(func() { hookRan = true })
+
+	service.FatalWith().Str("reason", "disk gone").Msg("cannot continue")
+	assert.NotPanics(t, func() {
+		service.PanicWith().Msg("unreachable state")
+		service.With().Str("component", "cat").Logger().PanicWith().Msg("from child")
+	})
+
+	assert.False(t, hookRan)
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 3)
+	assert.Equal(t, "fatal", entries[0]["level"])
+	assert.Equal(t, "disk gone", entries[0]["reason"])
+	assert.Equal(t, "panic", entries[1]["level"])
+	assert.Equal(t, "unreachable state", entries[1]["message"])
+	assert.Equal(t, "panic", entries[2]["level"])
+}
+
+func TestPanicWith_NeverSampledAndRecoverable(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{SampleBurst: 1, SamplePeriodMS: 60000})
+	file := logFileName(service)
+
+	// Use up the burst so that any further sampled event is dropped
+	service.InfoWith().Msg("burst")
+	service.InfoWith().Msg("sampled out")
+
+	assert.PanicsWithValue(t, "first", func() {
+		service.PanicWith().Str("k", "v").Msg("first")
+	})
+	assert.PanicsWithValue(t, "second", func() {
+		service.With().Str("component", "cat").Logger().PanicWith().Msg("second")
+	})
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	done := make(chan error, 1)
+	go func() { done <- service.Close() }()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close blocked after a recovered panic")
+	}
+
+	entries := readLogEntries(t, dir, file)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "burst", entries[0]["message"])
+	assert.Equal(t, "panic", entries[1]["level"])
+	assert.Equal(t, "v", entries[1]["k"])
+	assert.Equal(t, "second", entries[2]["message"])
+}
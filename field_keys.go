@@ -0,0 +1,32 @@
+package logging
+
+import "github.com/rs/zerolog"
+
+// reservedFieldSuffix is appended to user keys that collide with a reserved
+// field name when Config.StrictFieldKeys is set.
+const reservedFieldSuffix = "_field"
+
+// isReservedFieldKey reports whether key is written by the logger itself: the
+// level, message and timestamp fields, under their default or configured names.
+func isReservedFieldKey(key string) bool {
+	switch key {
+	case "level", "message", "time",
+		zerolog.LevelFieldName, zerolog.MessageFieldName, zerolog.TimestampFieldName:
+		return true
+	}
+	return false
+}
+
+// guardFieldKey renames key to <key>_field if Config.StrictFieldKeys is set and
+// key is reserved, so the line does not end up with duplicate keys. The first
+// rename of each key is reported with a Warn line.
+func (s *Service) guardFieldKey(key string) string {
+	if !s.Config.StrictFieldKeys || !isReservedFieldKey(key) {
+		return key
+	}
+	renamed := key + reservedFieldSuffix
+	if s.reservedKeysWarned.add(key) {
+		s.WarnWith().Str("field_key", key).Str("renamed_to", renamed).Msg("reserved field key renamed")
+	}
+	return renamed
+}
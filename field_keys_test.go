@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictFieldKeys(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{StrictFieldKeys: true})
+
+	service.InfoWith().Str("level", "x").Msg("first")
+	service.InfoWith().Str("level", "y").Int("message", 3).Msg("second")
+
+	data, err := os.ReadFile(filepath.Join(dir, logFileName(service)))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 4)
+	for _, line := range lines {
+		assert.Equal(t, 1, strings.Count(line, `"level":`), "duplicate level key in %s", line)
+		assert.Equal(t, 1, strings.Count(line, `"message":`), "duplicate message key in %s", line)
+	}
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	assert.Equal(t, "warn", entries[0]["level"])
+	assert.Equal(t, "reserved field key renamed", entries[0]["message"])
+	assert.Equal(t, "level", entries[0]["field_key"])
+	assert.Equal(t, "level_field", entries[0]["renamed_to"])
+
+	assert.Equal(t, "info", entries[1]["level"])
+	assert.Equal(t, "x", entries[1]["level_field"])
+
+	// The level warning is not repeated; the message key gets its own
+	assert.Equal(t, "message", entries[2]["field_key"])
+	assert.Equal(t, "second", entries[3]["message"])
+	assert.Equal(t, "y", entries[3]["level_field"])
+	assert.Equal(t, float64(3), entries[3]["message_field"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestStrictFieldKeys_Off(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.InfoWith().Str("user", "x").Msg("plain")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "x", entries[0]["user"])
+}
+
+func TestStrictFieldKeys_AfterTransformer(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{StrictFieldKeys: true})
+	service.SetAttrTransformer(func(key string, val interface{}) (string, interface{}) {
+		if key == "severity" {
+			return "level", val
+		}
+		return key, val
+	})
+
+	service.InfoWith().Str("severity", "high").Msg("transformed")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "info", entries[1]["level"])
+	assert.Equal(t, "high", entries[1]["level_field"])
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/rs/zerolog"
+)
+
+// fieldOrderWriter rewrites each JSON line so that the priority keys come first,
+// in the given order, followed by the remaining keys in their original order.
+// Lines that are not JSON objects are written unchanged.
+type fieldOrderWriter struct {
+	w        zerolog.LevelWriter
+	priority []string
+}
+
+// newFieldOrderWriter wraps w with key reordering. An empty priority list uses
+// the timestamp, level and message fields.
+func newFieldOrderWriter(w zerolog.LevelWriter, priority []string) *fieldOrderWriter {
+	if len(priority) == 0 {
+		priority = []string{zerolog.TimestampFieldName, zerolog.LevelFieldName, zerolog.MessageFieldName}
+	}
+	return &fieldOrderWriter{w: w, priority: priority}
+}
+
+// Write implements io.Writer.
+func (fw *fieldOrderWriter) Write(p []byte) (int, error) {
+	return fw.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+func (fw *fieldOrderWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	out, ok := reorderJSONLine(p, fw.priority)
+	if !ok {
+		return fw.w.WriteLevel(level, p)
+	}
+	if _, err := fw.w.WriteLevel(level, out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// reorderJSONLine returns p with its top-level keys reordered, or false if p is
+// not a single JSON object.
+func reorderJSONLine(p []byte, priority []string) ([]byte, bool) {
+	fields, ok := splitJSONObject(p)
+	if !ok {
+		return nil, false
+	}
+
+	var keys []string
+	values := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if _, dup := values[f.key]; !dup {
+			keys = append(keys, f.key)
+		}
+		values[f.key] = f.raw
+	}
+
+	out := make([]byte, 0, len(p))
+	out = append(out, '{')
+	appendField := func(key string) {
+		if len(out) > 1 {
+			out = append(out, ',')
+		}
+		quoted, _ := json.Marshal(key)
+		out = append(out, quoted...)
+		out = append(out, ':')
+		out = append(out, values[key]...)
+		delete(values, key)
+	}
+	for _, key := range priority {
+		if _, ok := values[key]; ok {
+			appendField(key)
+		}
+	}
+	for _, key := range keys {
+		if _, ok := values[key]; ok {
+			appendField(key)
+		}
+	}
+	out = append(out, '}')
+	if bytes.HasSuffix(p, []byte{'\n'}) {
+		out = append(out, '\n')
+	}
+	return out, true
+}
@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// firstKeys returns the first n top-level keys of each JSON line in the file.
+func firstKeys(t *testing.T, path string, n int) [][]string {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var result [][]string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		dec := json.NewDecoder(bytes.NewReader(sc.Bytes()))
+		_, err := dec.Token()
+		require.NoError(t, err)
+		var keys []string
+		for dec.More() && len(keys) < n {
+			tok, err := dec.Token()
+			require.NoError(t, err)
+			keys = append(keys, tok.(string))
+			var skip json.RawMessage
+			require.NoError(t, dec.Decode(&skip))
+		}
+		result = append(result, keys)
+	}
+	return result
+}
+
+func TestStableFieldOrder(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.WithTimestamp = true
+	service, dir := newFileTestService(t, cfg, Config{StableFieldOrder: true})
+
+	service.InfoWith().Str("zeta", "z").Int("alpha", 1).Msg("ordered")
+
+	lines := firstKeys(t, filepath.Join(dir, logFileName(service)), 5)
+	require.Len(t, lines, 1)
+	assert.Equal(t, []string{"time", "level", "message", "zeta", "alpha"}, lines[0])
+}
+
+func TestStableFieldOrder_Custom(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{
+		StableFieldOrder: true,
+		FieldOrder:       []string{"message", "request_id"},
+	})
+
+	service.InfoWith().Str("request_id", "r1").Msg("custom")
+
+	lines := firstKeys(t, filepath.Join(dir, logFileName(service)), 3)
+	require.Len(t, lines, 1)
+	assert.Equal(t, []string{"message", "request_id", "level"}, lines[0])
+}
+
+func TestReorderJSONLine_Invalid(t *testing.T) {
+	_, ok := reorderJSONLine([]byte("not json\n"), []string{"a"})
+	assert.False(t, ok)
+	_, ok = reorderJSONLine([]byte(`[1,2]`), []string{"a"})
+	assert.False(t, ok)
+}
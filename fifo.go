@@ -0,0 +1,24 @@
+package logging
+
+import "path/filepath"
+
+// maxFifoPending bounds the bytes a fifoWriter holds back while the reader is
+// slow; lines that do not fit are dropped.
+const maxFifoPending = 64 * 1024
+
+// fifoPath resolves Config.FifoPath, which is relative to WorkingDir unless absolute.
+func (s *Service) fifoPath() string {
+	if filepath.IsAbs(s.Config.FifoPath) {
+		return s.Config.FifoPath
+	}
+	return filepath.Join(s.WorkingDir, s.Config.FifoPath)
+}
+
+// DroppedFifoLines returns the number of lines not written to Config.FifoPath,
+// because no reader had the pipe open or the reader fell too far behind.
+func (s *Service) DroppedFifoLines() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.fifoDrops.Load()
+}
@@ -0,0 +1,19 @@
+//go:build !unix
+
+package logging
+
+import (
+	"io"
+
+	"github.com/Station-Manager/errors"
+	"go.uber.org/atomic"
+)
+
+// fifoSupported reports whether Config.FifoPath can be used on this platform.
+const fifoSupported = false
+
+// newFifoWriter always fails: named pipes are only supported on Unix.
+func newFifoWriter(path string, drops *atomic.Int64) (io.WriteCloser, error) {
+	const op errors.Op = "logging.newFifoWriter"
+	return nil, errors.New(op).Msg("FifoPath is only supported on Unix")
+}
@@ -0,0 +1,164 @@
+//go:build unix
+
+package logging
+
+import (
+	"io"
+	"os"
+	"sync"
+	"syscall"
+
+	"github.com/Station-Manager/errors"
+	"go.uber.org/atomic"
+)
+
+// fifoSupported reports whether Config.FifoPath can be used on this platform.
+const fifoSupported = true
+
+// fifoWriter writes lines to a named pipe without ever blocking. The pipe is
+// opened non-blocking on the first write that finds a reader (and reopened
+// after the reader goes away); until then lines are dropped. Bytes the pipe
+// does not accept yet are held back, up to maxFifoPending, so that lines are
+// never cut in half; a line that does not fit is dropped. Drops are counted.
+//
+// The raw descriptor is used instead of an *os.File because the runtime poller
+// would park the writing goroutine on a full pipe.
+type fifoWriter struct {
+	path  string
+	drops *atomic.Int64
+
+	mu      sync.Mutex
+	fd      int // -1 while no reader has the pipe open
+	pending []byte
+	closed  bool
+}
+
+// newFifoWriter creates the named pipe at path if it does not exist and returns
+// a writer for it. It fails if path exists and is not a named pipe.
+func newFifoWriter(path string, drops *atomic.Int64) (io.WriteCloser, error) {
+	const op errors.Op = "logging.newFifoWriter"
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		if err := syscall.Mkfifo(path, 0600); err != nil && !os.IsExist(err) {
+			return nil, errors.New(op).Errorf("syscall.Mkfifo: %w", err)
+		}
+	case err != nil:
+		return nil, errors.New(op).Errorf("os.Stat: %w", err)
+	case info.Mode()&os.ModeNamedPipe == 0:
+		return nil, errors.New(op).Msgf("FifoPath '%s' exists and is not a named pipe", path)
+	}
+	return &fifoWriter{path: path, drops: drops, fd: -1}, nil
+}
+
+// Write implements io.Writer. It never returns an error; undeliverable lines
+// are counted instead.
+func (fw *fifoWriter) Write(p []byte) (int, error) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.closed || !fw.open() {
+		fw.drops.Inc()
+		return len(p), nil
+	}
+	if !fw.flushPending() {
+		if len(fw.pending)+len(p) > maxFifoPending {
+			fw.drops.Inc()
+		} else {
+			fw.pending = append(fw.pending, p...)
+		}
+		return len(p), nil
+	}
+
+	n, err := fw.write(p)
+	if err != nil {
+		// The reader went away; this line is lost with it
+		fw.reset()
+		fw.drops.Inc()
+		return len(p), nil
+	}
+	if n < len(p) {
+		fw.pending = append(fw.pending, p[n:]...)
+	}
+	return len(p), nil
+}
+
+// open opens the pipe if it is not open yet. It returns false while there is
+// no reader.
+func (fw *fifoWriter) open() bool {
+	if fw.fd >= 0 {
+		return true
+	}
+	fd, err := syscall.Open(fw.path, syscall.O_WRONLY|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return false
+	}
+	fw.fd = fd
+	return true
+}
+
+// flushPending writes held back bytes and reports whether all of them were written.
+func (fw *fifoWriter) flushPending() bool {
+	if len(fw.pending) == 0 {
+		return true
+	}
+	n, err := fw.write(fw.pending)
+	if err != nil {
+		fw.reset()
+		return false
+	}
+	fw.pending = fw.pending[:copy(fw.pending, fw.pending[n:])]
+	return len(fw.pending) == 0
+}
+
+// write writes as much of p as the pipe accepts without blocking. A full pipe
+// is not an error.
+func (fw *fifoWriter) write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		n, err := syscall.Write(fw.fd, p[written:])
+		if n > 0 {
+			written += n
+		}
+		switch {
+		case err == syscall.EINTR:
+			continue
+		case err == syscall.EAGAIN:
+			return written, nil
+		case err != nil:
+			return written, err
+		case n == 0:
+			return written, nil
+		}
+	}
+	return written, nil
+}
+
+// reset closes the pipe after the reader went away, discarding held back bytes.
+func (fw *fifoWriter) reset() {
+	if fw.fd >= 0 {
+		_ = syscall.Close(fw.fd)
+		fw.fd = -1
+	}
+	if len(fw.pending) > 0 {
+		fw.drops.Inc()
+		fw.pending = fw.pending[:0]
+	}
+}
+
+// Close closes the pipe. Held back bytes are discarded. It is safe to call
+// multiple times.
+func (fw *fifoWriter) Close() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.closed {
+		return nil
+	}
+	fw.closed = true
+	if fw.fd < 0 {
+		return nil
+	}
+	err := syscall.Close(fw.fd)
+	fw.fd = -1
+	fw.pending = nil
+	return err
+}
@@ -0,0 +1,107 @@
+//go:build unix
+
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFifoPath(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{FifoPath: "collector.fifo"})
+	path := filepath.Join(service.WorkingDir, "collector.fifo")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&os.ModeNamedPipe)
+
+	// Without a reader the line is dropped instead of blocking
+	service.InfoWith().Msg("nobody listening")
+	assert.EqualValues(t, 1, service.DroppedFifoLines())
+
+	reader, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	require.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	received := make(chan []byte, 1)
+	go func() {
+		var data []byte
+		buf := make([]byte, 4096)
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			n, _ := reader.Read(buf)
+			data = append(data, buf[:n]...)
+			if bytes.IndexByte(data, '\n') >= 0 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		received <- data
+	}()
+
+	service.InfoWith().Str("sidecar", "yes").Msg("hello fifo")
+
+	var line []byte
+	select {
+	case line = <-received:
+	case <-time.After(10 * time.Second):
+		t.Fatal("no line received from the fifo")
+	}
+	line, err = bufio.NewReader(bytes.NewReader(line)).ReadBytes('\n')
+	require.NoError(t, err)
+
+	var entry logEntry
+	require.NoError(t, json.Unmarshal(line, &entry))
+	assert.Equal(t, "hello fifo", entry["message"])
+	assert.Equal(t, "yes", entry["sidecar"])
+	assert.EqualValues(t, 1, service.DroppedFifoLines())
+
+	require.NoError(t, service.Close())
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestFifoPath_ExistingRegularFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "not-a-pipe"), nil, 0600))
+
+	service := &Service{
+		WorkingDir:    tmpDir,
+		ConfigService: newTestConfigService(validLoggingConfig()),
+		Config:        Config{FifoPath: "not-a-pipe"},
+	}
+	err := service.Initialize()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a named pipe")
+}
+
+func TestFifoWriter_HoldsBackWhenReaderIsSlow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slow.fifo")
+	var service Service
+	w, err := newFifoWriter(path, &service.fifoDrops)
+	require.NoError(t, err)
+	defer func() { _ = w.Close() }()
+
+	reader, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	require.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	// Fill the pipe buffer and the held back bytes without reading
+	line := append(bytes.Repeat([]byte("x"), 1023), '\n')
+	start := time.Now()
+	for i := 0; i < 1024; i++ {
+		n, err := w.Write(line)
+		require.NoError(t, err)
+		require.Equal(t, len(line), n)
+	}
+	assert.Less(t, time.Since(start), 5*time.Second, "writes must not block")
+	assert.Positive(t, service.DroppedFifoLines())
+}
@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileConsoleFormat(t *testing.T) {
+	t.Run("console format", func(t *testing.T) {
+		service, dir := newFileTestService(t, validLoggingConfig(), Config{FileConsoleFormat: true})
+		service.InfoWith().Str("user", "u1").Msg("human readable")
+
+		data, err := os.ReadFile(filepath.Join(dir, logFileName(service)))
+		require.NoError(t, err)
+		out := string(data)
+		assert.Contains(t, out, "INF")
+		assert.Contains(t, out, "human readable")
+		assert.Contains(t, out, "user=u1")
+		assert.NotContains(t, out, `{"level":"info"`)
+	})
+
+	t.Run("json by default", func(t *testing.T) {
+		service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+		service.InfoWith().Msg("machine readable")
+
+		data, err := os.ReadFile(filepath.Join(dir, logFileName(service)))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `{"level":"info"`)
+	})
+}
@@ -0,0 +1,19 @@
+package logging
+
+import "github.com/rs/zerolog"
+
+// SetFilter installs a predicate consulted when an event is finalized with
+// Msg/Msgf/Send. Returning false drops the event: nothing is written, but the
+// shutdown accounting is still released. Fatal and panic events are never
+// filtered. Passing nil removes the filter. The filter runs on the logging
+// goroutine and must be safe for concurrent use.
+func (s *Service) SetFilter(fn func(level zerolog.Level, msg string) bool) {
+	if s == nil {
+		return
+	}
+	if fn == nil {
+		s.filter.Store(nil)
+		return
+	}
+	s.filter.Store(&fn)
+}
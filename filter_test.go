@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetFilter_DropsMatchingEvents(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	var seenLevels []zerolog.Level
+	service.SetFilter(func(level zerolog.Level, msg string) bool {
+		seenLevels = append(seenLevels, level)
+		return !strings.Contains(msg, "secret")
+	})
+
+	service.InfoWith().Msg("public message")
+	service.InfoWith().Str("k", "v").Msg("contains secret token")
+	service.WarnWith().Msgf("formatted %s", "secret")
+	service.With().Str("ctx", "child").Logger().ErrorWith().Msg("child secret")
+	service.DebugWith().Msgf("formatted %d", 42)
+	service.InfoWith().Send()
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 3)
+	assert.Equal(t, "public message", entries[0]["message"])
+	assert.Equal(t, "formatted 42", entries[1]["message"])
+	assert.NotContains(t, entries[2], "message")
+	assert.Equal(t, []zerolog.Level{
+		zerolog.InfoLevel, zerolog.InfoLevel, zerolog.WarnLevel,
+		zerolog.ErrorLevel, zerolog.DebugLevel, zerolog.InfoLevel,
+	}, seenLevels)
+
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	// Removing the filter restores normal behaviour
+	service.SetFilter(nil)
+	service.InfoWith().Msg("another secret")
+	assert.Len(t, readLogEntries(t, dir, logFileName(service)), 4)
+}
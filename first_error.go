@@ -0,0 +1,40 @@
+package logging
+
+import "github.com/rs/zerolog"
+
+// OnFirstError registers fn to be called once, with the message and error of
+// the first Error-level event with an error attached through Err that is
+// written after the call, e.g. to raise an alert the first time something goes
+// wrong after startup. Register it right after Initialize. fn runs on the
+// logging goroutine after the line was written; a filtered or sampled-out event
+// does not count. Passing nil removes the callback; fn is never called a
+// second time, even if replaced.
+func (s *Service) OnFirstError(fn func(msg string, err error)) {
+	if s == nil {
+		return
+	}
+	if fn == nil {
+		s.onFirstError.Store(nil)
+		return
+	}
+	s.onFirstError.Store(&fn)
+}
+
+// firstErrorPending reports whether an OnFirstError callback is waiting for its
+// error, so that events only keep their error when it may be needed.
+func (s *Service) firstErrorPending() bool {
+	return s.onFirstError.Load() != nil && !s.firstErrorFired.Load()
+}
+
+// notifyFirstError calls the OnFirstError callback if this written event is the
+// first Error-level event with an error.
+func (e *trackedLogEvent) notifyFirstError(msg string) {
+	if e.err == nil || e.level != zerolog.ErrorLevel {
+		return
+	}
+	fn := e.service.onFirstError.Load()
+	if fn == nil || !e.service.firstErrorFired.CompareAndSwap(false, true) {
+		return
+	}
+	(*fn)(msg, e.err)
+}
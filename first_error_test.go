@@ -0,0 +1,61 @@
+package logging
+
+import (
+	stderrs "errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_OnFirstError(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	type call struct {
+		msg string
+		err error
+	}
+	var calls []call
+	service.OnFirstError(func(msg string, err error) {
+		calls = append(calls, call{msg: msg, err: err})
+	})
+
+	first := stderrs.New("dial tcp: connection refused")
+	second := stderrs.New("disk full")
+
+	// Neither counts: wrong level, no error attached
+	service.WarnWith().Err(first).Msg("retrying")
+	service.ErrorWith().Msg("no error attached")
+
+	service.ErrorWith().Err(first).Msgf("connect to %s failed", "db")
+	service.ErrorWith().Err(second).Msg("write failed")
+
+	require.Len(t, calls, 1)
+	assert.Equal(t, "connect to db failed", calls[0].msg)
+	assert.Same(t, first, calls[0].err)
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	assert.Len(t, entries, 4)
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestService_OnFirstError_FilteredDoesNotCount(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+
+	var got []string
+	service.OnFirstError(func(msg string, _ error) { got = append(got, msg) })
+	service.SetFilter(func(_ zerolog.Level, msg string) bool { return msg != "dropped" })
+
+	service.ErrorWith().Err(stderrs.New("x")).Msg("dropped")
+	service.With().Str("component", "cat").Logger().ErrorWith().Err(stderrs.New("y")).Msg("from child")
+	service.ErrorWith().Err(stderrs.New("z")).Msg("later")
+
+	assert.Equal(t, []string{"from child"}, got)
+
+	// Removing the callback is safe; nil services are ignored
+	service.OnFirstError(nil)
+	service.ErrorWith().Err(stderrs.New("z")).Msg("after removal")
+	var nilService *Service
+	nilService.OnFirstError(func(string, error) {})
+}
@@ -0,0 +1,31 @@
+package logging
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Go runs fn in a new goroutine that cannot crash the process: a panic in fn
+// is recovered and logged at Error level with panic and stack, and the
+// goroutine returns. A nil fn is ignored.
+func (s *Service) Go(fn func()) {
+	if fn == nil {
+		return
+	}
+	go func() {
+		defer s.recoverGoroutine()
+		fn()
+	}()
+}
+
+// recoverGoroutine must be deferred directly; it logs a recovered panic.
+func (s *Service) recoverGoroutine() {
+	p := recover()
+	if p == nil {
+		return
+	}
+	s.ErrorWith().
+		Str("panic", fmt.Sprint(p)).
+		Str("stack", string(debug.Stack())).
+		Msg("goroutine panic recovered")
+}
@@ -0,0 +1,33 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGo_RecoversPanic(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	// Creates the log file so that it can be polled below
+	service.InfoWith().Msg("start")
+
+	service.Go(func() { panic("worker exploded") })
+	ran := make(chan struct{})
+	service.Go(func() { close(ran) })
+	service.Go(nil)
+	<-ran
+
+	var entries []logEntry
+	require.Eventually(t, func() bool {
+		entries = readLogEntries(t, dir, logFileName(service))
+		return len(entries) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	entry := entries[1]
+	assert.Equal(t, "error", entry["level"])
+	assert.Equal(t, "goroutine panic recovered", entry["message"])
+	assert.Equal(t, "worker exploded", entry["panic"])
+	assert.Contains(t, entry["stack"], "TestGo_RecoversPanic")
+}
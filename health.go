@@ -0,0 +1,52 @@
+package logging
+
+// HealthReport is a snapshot of the service's internal state, see Health.
+type HealthReport struct {
+	// Initialized reports whether the service is accepting log events.
+	Initialized bool
+	// Level is the effective level, including SetQuiet; empty when not initialized.
+	Level string
+	// Quiet reports whether SetQuiet(true) is in effect.
+	Quiet bool
+	// ActiveOperations and PeakActiveOperations are the current and highest
+	// number of in-flight logging operations (see ActiveOperations).
+	ActiveOperations     int32
+	PeakActiveOperations int32
+	// LastWriteError is the most recent file write error, or nil.
+	LastWriteError error
+	// DroppedWrites counts lines dropped because of Config.WriteTimeoutMS.
+	DroppedWrites int64
+	// DroppedFifoLines counts lines Config.FifoPath could not deliver.
+	DroppedFifoLines int64
+	// DroppedBufferedBytes counts bytes Config.FlushIntervalMS buffering lost
+	// to file write errors.
+	DroppedBufferedBytes int64
+	// UnbalancedReleases counts refused releases of untracked operations, a
+	// sign of a logging bug.
+	UnbalancedReleases int64
+}
+
+// Health returns a snapshot of the service's state for monitoring. It is safe
+// to call at any time, including on a nil or uninitialized service.
+func (s *Service) Health() HealthReport {
+	if s == nil {
+		return HealthReport{}
+	}
+	report := HealthReport{
+		Initialized:          s.isInitialized.Load(),
+		Quiet:                s.quiet.Load(),
+		ActiveOperations:     s.activeOps.Load(),
+		PeakActiveOperations: s.peakOps.Load(),
+		LastWriteError:       s.lastWriteErr.Load(),
+		DroppedWrites:        s.writeDrops.Load(),
+		DroppedFifoLines:     s.fifoDrops.Load(),
+		DroppedBufferedBytes: s.bufferedDrops.Load(),
+		UnbalancedReleases:   s.unbalancedReleases.Load(),
+	}
+	if report.Initialized {
+		if logger := s.logger.Load(); logger != nil {
+			report.Level = logger.GetLevel().String()
+		}
+	}
+	return report
+}
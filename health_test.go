@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Health(t *testing.T) {
+	var nilService *Service
+	assert.Equal(t, HealthReport{}, nilService.Health())
+
+	cfg := validLoggingConfig()
+	cfg.ConsoleLogging = false
+	cfg.FileLogging = true
+	service := &Service{WorkingDir: t.TempDir(), ConfigService: newTestConfigService(cfg)}
+
+	before := service.Health()
+	assert.False(t, before.Initialized)
+	assert.Empty(t, before.Level)
+	assert.NoError(t, before.LastWriteError)
+
+	assert.NoError(t, service.Initialize())
+	t.Cleanup(func() { _ = service.Close() })
+
+	event := service.InfoWith()
+	after := service.Health()
+	event.Msg("in flight")
+	assert.True(t, after.Initialized)
+	assert.Equal(t, "debug", after.Level)
+	assert.False(t, after.Quiet)
+	assert.Equal(t, int32(1), after.ActiveOperations)
+	assert.Equal(t, int32(1), after.PeakActiveOperations)
+	assert.NoError(t, after.LastWriteError)
+	assert.Zero(t, after.DroppedWrites)
+	assert.Zero(t, after.DroppedFifoLines)
+	assert.Zero(t, after.UnbalancedReleases)
+
+	// A failed file write, as reported by the file writer
+	service.reportWriteError(errDiskFull)
+	service.SetQuiet(true)
+	degraded := service.Health()
+	assert.ErrorIs(t, degraded.LastWriteError, errDiskFull)
+	assert.Equal(t, "error", degraded.Level)
+	assert.True(t, degraded.Quiet)
+	assert.Equal(t, int32(0), degraded.ActiveOperations)
+
+	assert.NoError(t, service.Close())
+	assert.False(t, service.Health().Initialized)
+	assert.Empty(t, service.Health().Level)
+}
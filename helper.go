@@ -4,16 +4,18 @@ import (
 	stderrs "errors"
 	"fmt"
 	"runtime"
+	"strconv"
 	"strings"
 
 	smerrors "github.com/Station-Manager/errors"
 	"github.com/rs/zerolog"
 )
 
-// parseLevel parses a string log level into a zerolog.Level.
+// parseLevel parses a string log level, or a registered alias of one (see
+// RegisterLevelAlias), into a zerolog.Level.
 // Returns zerolog.NoLevel and an error if parsing fails.
 func parseLevel(level string) (zerolog.Level, error) {
-	l, err := zerolog.ParseLevel(level)
+	l, err := zerolog.ParseLevel(canonicalLevel(level))
 	if err != nil {
 		return zerolog.NoLevel, err
 	}
@@ -69,12 +71,73 @@ func buildErrorChain(err error) (chain []string, ops []string, root string, root
 	return
 }
 
-// joinChain returns a single string for the error chain separated by " -> ".
-func joinChain(chain []string) string {
+// rootError returns the innermost error of err's cause chain, following the same
+// rules as buildErrorChain.
+func rootError(err error) error {
+	const maxDepth = 50
+	seen := map[string]bool{}
+	for visited := 0; err != nil && visited < maxDepth; visited++ {
+		var next error
+		if dErr, ok := smerrors.AsDetailedError(err); ok && dErr != nil {
+			next = dErr.Cause()
+		} else {
+			msg := err.Error()
+			if seen[msg] {
+				return err
+			}
+			seen[msg] = true
+			next = stderrs.Unwrap(err)
+		}
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+	return err
+}
+
+// defaultErrorHistorySeparator is used when Config.ErrorHistorySeparator is empty.
+const defaultErrorHistorySeparator = " -> "
+
+// joinChain returns a single string for the error chain separated by sep (" -> "
+// when empty). When maxLinks > 0 only the outermost maxLinks links are kept,
+// followed by a " (+N more)" suffix.
+func joinChain(chain []string, sep string, maxLinks int) string {
 	if len(chain) == 0 {
 		return ""
 	}
-	return strings.Join(chain, " -> ")
+	if sep == emptyString {
+		sep = defaultErrorHistorySeparator
+	}
+	if maxLinks <= 0 || len(chain) <= maxLinks {
+		return strings.Join(chain, sep)
+	}
+	return strings.Join(chain[:maxLinks], sep) + " (+" + strconv.Itoa(len(chain)-maxLinks) + " more)"
+}
+
+// filterOps blanks every op that does not start with one of the allowed
+// prefixes, keeping the slice aligned with the error chain. An empty allow list
+// keeps all ops.
+func filterOps(ops []string, allow []string) []string {
+	if len(allow) == 0 {
+		return ops
+	}
+	for i, op := range ops {
+		if !hasAnyPrefix(op, allow) {
+			ops[i] = emptyString
+		}
+	}
+	return ops
+}
+
+// hasAnyPrefix reports whether s starts with one of prefixes.
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
 }
 
 // logEventBuilder creates a log event for the given level.
@@ -82,26 +145,36 @@ func joinChain(chain []string) string {
 // of the logging operation, preventing race conditions with Close().
 // If the level is disabled on the logger, it returns a no-op LogEvent.
 func logEventBuilder(s *Service, level zerolog.Level) LogEvent {
-	if s == nil || !s.isInitialized.Load() {
+	return buildLogEvent(s, level, false)
+}
+
+// auditEventBuilder creates an audit event that bypasses the level filter and
+// sampling. See Service.AuditWith.
+func auditEventBuilder(s *Service) LogEvent {
+	return buildLogEvent(s, zerolog.NoLevel, true)
+}
+
+// buildLogEvent implements logEventBuilder and auditEventBuilder. It must be
+// called through one of them so that the caller location depth is constant.
+func buildLogEvent(s *Service, level zerolog.Level, audit bool) LogEvent {
+	if s == nil {
 		return newLogEvent(nil)
 	}
-	if level == zerolog.NoLevel {
+	if !s.isInitialized.Load() {
+		s.warnUninitialized()
 		return newLogEvent(nil)
 	}
-
-	// Check if initialized BEFORE incrementing counters to avoid leaks during shutdown
-	if !s.isInitialized.Load() {
+	if level == zerolog.NoLevel && !audit {
 		return newLogEvent(nil)
 	}
 
 	// Increment active operations counter before acquiring lock
-	s.activeOps.Add(1)
-	s.wg.Add(1)
+	s.trackOp()
 
 	// Debug: Track where this operation was created
 	var location string
 	if s.LoggingConfig.ShutdownTimeoutWarning {
-		_, file, line, ok := runtime.Caller(2)
+		_, file, line, ok := runtime.Caller(3)
 		if ok {
 			location = fmt.Sprintf("%s:%d", file, line)
 			s.mu.Lock()
@@ -119,84 +192,57 @@ func logEventBuilder(s *Service, level zerolog.Level) LogEvent {
 	// Double-check after acquiring lock (TOCTOU protection)
 	if !s.isInitialized.Load() {
 		s.mu.RUnlock()
-		s.activeOps.Add(-1)
-		s.wg.Done()
-		// Also decrement location counter if tracking is enabled
-		if location != "" {
-			s.mu.Lock()
-			if s.activeOpLocations != nil {
-				s.activeOpLocations[location]--
-				if s.activeOpLocations[location] <= 0 {
-					delete(s.activeOpLocations, location)
-				}
-			}
-			s.mu.Unlock()
-		}
+		s.releaseOp(location)
 		return newLogEvent(nil)
 	}
 
 	logger := s.logger.Load()
 	if logger == nil {
 		s.mu.RUnlock()
-		s.activeOps.Add(-1)
-		s.wg.Done()
-		// Also decrement location counter if tracking is enabled
-		if location != "" {
-			s.mu.Lock()
-			if s.activeOpLocations != nil {
-				s.activeOpLocations[location]--
-				if s.activeOpLocations[location] <= 0 {
-					delete(s.activeOpLocations, location)
-				}
-			}
-			s.mu.Unlock()
-		}
+		s.releaseOp(location)
 		return newLogEvent(nil)
 	}
 
-	if logger.GetLevel() > level {
+	if !audit && logger.GetLevel() > level {
 		s.mu.RUnlock()
-		s.activeOps.Add(-1)
-		s.wg.Done()
-		// Also decrement location counter if tracking is enabled
-		if location != "" {
-			s.mu.Lock()
-			if s.activeOpLocations != nil {
-				s.activeOpLocations[location]--
-				if s.activeOpLocations[location] <= 0 {
-					delete(s.activeOpLocations, location)
-				}
-			}
-			s.mu.Unlock()
-		}
+		s.releaseOp(location)
 		return newLogEvent(nil) // Return early if level is not enabled
 	}
 
 	var event *zerolog.Event
-	switch level {
-	case zerolog.DebugLevel:
+	switch {
+	case audit:
+		// NoLevel passes every level threshold; drop the sampler so audits are never sampled out
+		unsampled := logger.Sample(nil)
+		event = unsampled.Log().
+			Str(zerolog.LevelFieldName, auditLevelName).
+			Bool("audit", true)
+	case level == zerolog.DebugLevel:
 		event = logger.Debug()
-	case zerolog.InfoLevel:
+	case level == zerolog.InfoLevel:
 		event = logger.Info()
-	case zerolog.WarnLevel:
+	case level == zerolog.WarnLevel:
 		event = logger.Warn()
-	case zerolog.ErrorLevel:
+	case level == zerolog.ErrorLevel:
 		event = logger.Error()
-	case zerolog.FatalLevel:
-		event = logger.Fatal()
-	case zerolog.PanicLevel:
-		event = logger.Panic()
-	case zerolog.TraceLevel:
+	case level == zerolog.FatalLevel:
+		// Exit is handled by the tracked event (see Service.fatalExit); fatal events are never sampled
+		unsampled := logger.Sample(nil)
+		event = unsampled.WithLevel(zerolog.FatalLevel)
+	case level == zerolog.PanicLevel:
+		// zerolog may panic while creating the event, so the shutdown lock is released first
+		s.mu.RUnlock()
+		return newTrackedLevelLogEvent(s.panicEvent(logger, location), s, level, location)
+	case level == zerolog.TraceLevel:
 		event = logger.Trace()
 	default:
 		s.mu.RUnlock()
-		s.activeOps.Add(-1)
-		s.wg.Done()
+		s.releaseOp(location)
 		return newLogEvent(nil)
 	}
 
 	s.mu.RUnlock()
 
 	// Wrap the event to decrement counter when done
-	return newTrackedLogEvent(event, s, location)
+	return newTrackedLevelLogEvent(event, s, level, location)
 }
@@ -0,0 +1,141 @@
+package logging
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+)
+
+// timestampHook adds the timestamp field using per-service settings instead of
+// zerolog's process-wide TimeFieldFormat, so that several services with
+// different settings can coexist and Initialize never mutates globals. The time
+// is read from the service clock (see SetClock) on every event, or from
+// zerolog.TimestampFunc when no clock is set.
+type timestampHook struct {
+	utc    bool
+	format string
+	clock  *atomic.Pointer[func() time.Time] // Service clock; nil or unset means zerolog.TimestampFunc
+}
+
+// Run implements zerolog.Hook.
+func (h timestampHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	var now time.Time
+	if fn := h.clockFunc(); fn != nil {
+		now = fn()
+	} else {
+		now = zerolog.TimestampFunc()
+	}
+	if h.utc {
+		now = now.UTC()
+	}
+	switch h.format {
+	case emptyString:
+		e.Time(zerolog.TimestampFieldName, now)
+	case zerolog.TimeFormatUnixMs:
+		e.Int64(zerolog.TimestampFieldName, now.UnixMilli())
+	case zerolog.TimeFormatUnixMicro:
+		e.Int64(zerolog.TimestampFieldName, now.UnixMicro())
+	case zerolog.TimeFormatUnixNano:
+		e.Int64(zerolog.TimestampFieldName, now.UnixNano())
+	default:
+		e.Str(zerolog.TimestampFieldName, now.Format(h.format))
+	}
+}
+
+// callerHook adds the caller field like zerolog's CallerWithSkipFrameCount, but
+// shortens the file path per service instead of through the process-wide
+// zerolog.CallerMarshalFunc.
+type callerHook struct {
+	skip       int
+	trimPrefix string
+	auto       bool
+}
+
+// callerHookFrames is the number of frames between the logging call site and
+// Run that zerolog's own caller hook accounts for, minus the Event.caller frame
+// that Run does not go through.
+const callerHookFrames = 1
+
+// Run implements zerolog.Hook.
+func (h callerHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	_, file, line, ok := runtime.Caller(h.skip + callerHookFrames)
+	if !ok {
+		return
+	}
+	e.Str(zerolog.CallerFieldName, h.trim(file)+":"+strconv.Itoa(line))
+}
+
+// trim removes the configured prefix from file or, in auto mode, keeps only the
+// last directory and the file name.
+func (h callerHook) trim(file string) string {
+	if h.trimPrefix != emptyString {
+		return strings.TrimPrefix(file, h.trimPrefix)
+	}
+	if h.auto {
+		if i := strings.LastIndexByte(file, '/'); i > 0 {
+			if j := strings.LastIndexByte(file[:i], '/'); j >= 0 {
+				return file[j+1:]
+			}
+		}
+	}
+	return file
+}
+
+// goroutineIDHook adds the goroutine_id field. The ID is parsed from the header
+// of runtime.Stack, which costs roughly a microsecond per event.
+type goroutineIDHook struct{}
+
+// Run implements zerolog.Hook.
+func (goroutineIDHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	if id, ok := goroutineID(); ok {
+		e.Uint64(goroutineIDFieldName, id)
+	}
+}
+
+// goroutineID returns the current goroutine's ID from the "goroutine N [" header
+// of its stack trace.
+func goroutineID() (uint64, bool) {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b, ok := bytes.CutPrefix(b, []byte("goroutine "))
+	if !ok {
+		return 0, false
+	}
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	return id, err == nil
+}
+
+// severityHook adds the severity field holding the syslog severity number of
+// the event's level.
+type severityHook struct{}
+
+// Run implements zerolog.Hook.
+func (severityHook) Run(e *zerolog.Event, level zerolog.Level, _ string) {
+	e.Int(severityFieldName, syslogSeverity(level))
+}
+
+// syslogSeverity maps a zerolog level to a syslog severity number (RFC 5424).
+func syslogSeverity(level zerolog.Level) int {
+	switch level {
+	case zerolog.PanicLevel:
+		return 0 // emergency
+	case zerolog.FatalLevel:
+		return 2 // critical
+	case zerolog.ErrorLevel:
+		return 3 // error
+	case zerolog.WarnLevel:
+		return 4 // warning
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		return 7 // debug
+	default:
+		return 6 // informational, also for NoLevel
+	}
+}
@@ -0,0 +1,105 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamp_UTCAndFormat(t *testing.T) {
+	const layout = "2006-01-02T15:04:05.000Z07:00"
+
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{
+		TimestampUTC:    true,
+		TimestampFormat: layout,
+	})
+
+	service.InfoWith().Msg("stamped")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	raw, ok := entries[0]["time"].(string)
+	require.True(t, ok, "time field should be a string")
+
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{3}Z$`, raw)
+	ts, err := time.Parse(layout, raw)
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, ts.Location())
+	assert.WithinDuration(t, time.Now(), ts, time.Minute)
+}
+
+func TestTimestamp_UnixMs(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{TimestampFormat: "UNIXMS"})
+
+	service.InfoWith().Msg("stamped")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	ms, ok := entries[0]["time"].(float64)
+	require.True(t, ok, "time field should be numeric")
+	assert.InDelta(t, float64(time.Now().UnixMilli()), ms, float64(time.Minute.Milliseconds()))
+}
+
+func TestIncludeGoroutineID(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{IncludeGoroutineID: true})
+
+	service.InfoWith().Msg("main")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		service.InfoWith().Msg("worker")
+	}()
+	<-done
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	mainID, ok := entries[0][goroutineIDFieldName].(float64)
+	require.True(t, ok)
+	workerID, ok := entries[1][goroutineIDFieldName].(float64)
+	require.True(t, ok)
+	assert.Greater(t, mainID, float64(0))
+	assert.NotEqual(t, mainID, workerID)
+}
+
+func TestIncludeGoroutineID_OffByDefault(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.InfoWith().Msg("plain")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.NotContains(t, entries[0], goroutineIDFieldName)
+}
+
+func TestEmitNumericSeverity(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{EmitNumericSeverity: true})
+
+	service.ErrorWith().Msg("failed")
+	service.InfoWith().Msg("started")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "error", entries[0]["level"])
+	assert.Equal(t, float64(3), entries[0][severityFieldName])
+	assert.Equal(t, "info", entries[1]["level"])
+	assert.Equal(t, float64(6), entries[1][severityFieldName])
+}
+
+func TestSyslogSeverity(t *testing.T) {
+	cases := map[zerolog.Level]int{
+		zerolog.TraceLevel: 7,
+		zerolog.DebugLevel: 7,
+		zerolog.InfoLevel:  6,
+		zerolog.NoLevel:    6,
+		zerolog.WarnLevel:  4,
+		zerolog.ErrorLevel: 3,
+		zerolog.FatalLevel: 2,
+		zerolog.PanicLevel: 0,
+	}
+	for level, want := range cases {
+		assert.Equal(t, want, syslogSeverity(level), level.String())
+	}
+}
@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// statusRecorder wraps an http.ResponseWriter to capture the status code and
+// the number of body bytes written.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.status = code
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += n
+	return n, err
+}
+
+// Unwrap exposes the underlying ResponseWriter to http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// HTTPMiddleware returns a net/http middleware that logs one line per request
+// with method, path, status, duration_ms and bytes. The line is emitted at Info
+// level, Warn for 4xx responses and Error for 5xx responses, using a per-request
+// context logger carrying the method and path.
+// A panic in next is recovered: an Error line with panic and stack is logged, a
+// 500 is written if the handler had not yet sent a status, and the request line
+// follows as usual. http.ErrAbortHandler is re-panicked, as net/http expects.
+func (s *Service) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		reqLogger := s.With().
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Logger()
+
+		serveRecovering(next, rec, r, reqLogger)
+
+		var event LogEvent
+		switch {
+		case rec.status >= http.StatusInternalServerError:
+			event = reqLogger.ErrorWith()
+		case rec.status >= http.StatusBadRequest:
+			event = reqLogger.WarnWith()
+		default:
+			event = reqLogger.InfoWith()
+		}
+		event.Int("status", rec.status).
+			Dur("duration_ms", time.Since(start)).
+			Int("bytes", rec.bytes).
+			Msg("http request")
+	})
+}
+
+// serveRecovering calls next and turns a panic into an Error line on reqLogger
+// and, if nothing was written yet, a 500 response.
+func serveRecovering(next http.Handler, rec *statusRecorder, r *http.Request, reqLogger Logger) {
+	defer func() {
+		p := recover()
+		if p == nil {
+			return
+		}
+		if p == http.ErrAbortHandler {
+			panic(p)
+		}
+		reqLogger.ErrorWith().
+			Str("panic", fmt.Sprint(p)).
+			Str("stack", string(debug.Stack())).
+			Msg("http handler panic")
+		if !rec.wroteHeader {
+			http.Error(rec, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	}()
+	next.ServeHTTP(rec, r)
+}
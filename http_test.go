@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	handler := service.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(2 * time.Millisecond)
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte("hello"))
+		}
+	}))
+
+	for _, path := range []string{"/ok", "/missing", "/broken"} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 3)
+
+	expected := []struct {
+		path   string
+		status float64
+		level  string
+	}{
+		{"/ok", 200, "info"},
+		{"/missing", 404, "warn"},
+		{"/broken", 500, "error"},
+	}
+	for i, want := range expected {
+		entry := entries[i]
+		assert.Equal(t, "GET", entry["method"])
+		assert.Equal(t, want.path, entry["path"])
+		assert.Equal(t, want.status, entry["status"])
+		assert.Equal(t, want.level, entry["level"])
+		assert.Greater(t, entry["duration_ms"], float64(0))
+	}
+	assert.Equal(t, float64(5), entries[0]["bytes"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestHTTPMiddleware_RecoversPanic(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	handler := service.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(errors.New("nil map write"))
+	}))
+
+	rr := httptest.NewRecorder()
+	require.NotPanics(t, func() {
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items", nil))
+	})
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+
+	panicLine := entries[0]
+	assert.Equal(t, "error", panicLine["level"])
+	assert.Equal(t, "http handler panic", panicLine["message"])
+	assert.Equal(t, "nil map write", panicLine["panic"])
+	assert.Contains(t, panicLine["stack"], "TestHTTPMiddleware_RecoversPanic")
+	assert.Equal(t, "POST", panicLine["method"])
+	assert.Equal(t, "/items", panicLine["path"])
+
+	assert.Equal(t, "http request", entries[1]["message"])
+	assert.Equal(t, float64(500), entries[1]["status"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestHTTPMiddleware_AbortHandlerRepanics(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+
+	handler := service.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
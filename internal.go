@@ -1,22 +1,39 @@
 package logging
 
 import (
+	"github.com/Station-Manager/errors"
 	"github.com/rs/zerolog"
 	"gopkg.in/natefinch/lumberjack.v2"
 	"io"
-	"os"
 	"path/filepath"
+	"time"
 )
 
 // initializeRollingFileLogger configures a lumberjack logger for file rotation
 // using the configured size/age/backup limits. The filename is derived from
-// the executable name plus .log, written under RelLogFileDir relative to WorkingDir.
+// the executable name plus .log (or Config.LogFileName when set), written under
+// RelLogFileDir relative to WorkingDir.
 func (s *Service) initializeRollingFileLogger(exeName string) *lumberjack.Logger {
+	if s.Config.LogFileName != emptyString {
+		return s.newRollingFileLogger(s.Config.LogFileName)
+	}
 	if exeName == emptyString {
 		exeName = "app"
 	}
 
-	path := filepath.Join(s.WorkingDir, s.LoggingConfig.RelLogFileDir, exeName+".log")
+	return s.newRollingFileLogger(exeName + ".log")
+}
+
+// newRollingFileLogger creates a lumberjack logger for the given file name under
+// RelLogFileDir, using the configured rotation limits.
+func (s *Service) newRollingFileLogger(name string) *lumberjack.Logger {
+	return s.newRollingFileLoggerIn(s.LoggingConfig.RelLogFileDir, name)
+}
+
+// newRollingFileLoggerIn creates a lumberjack logger for the given file name under
+// relDir (relative to WorkingDir), using the configured rotation limits.
+func (s *Service) newRollingFileLoggerIn(relDir, name string) *lumberjack.Logger {
+	path := filepath.Join(s.WorkingDir, relDir, name)
 
 	return &lumberjack.Logger{
 		Filename:   path,
@@ -29,32 +46,72 @@ func (s *Service) initializeRollingFileLogger(exeName string) *lumberjack.Logger
 
 // initializeWriters creates the set of io.Writer targets for the logger based on configuration.
 // If both console and file logging are disabled, file logging is enabled by default for safety.
-// The method also stores the file writer on the Service for later Close().
-func (s *Service) initializeWriters(logfile string) []io.Writer {
+// The method also stores the file writers (and the buffering wrapper, if any) on the
+// Service for later Close().
+func (s *Service) initializeWriters(logfile string) ([]io.Writer, error) {
+	const op errors.Op = "logging.Service.initializeWriters"
 	var writers []io.Writer
 
-	// Create a local copy to avoid mutating shared config
-	fileLogging := s.LoggingConfig.FileLogging
+	// Local copies avoid mutating shared config; if both writers are disabled,
+	// the file writer is enabled
+	fileLogging := fileLoggingEnabled(s.LoggingConfig)
 	consoleLogging := s.LoggingConfig.ConsoleLogging
 
-	// If both writers are disabled, enable the file writer
-	if !consoleLogging && !fileLogging {
-		fileLogging = true
-	}
 	if fileLogging {
 		s.fileWriter = s.initializeRollingFileLogger(logfile)
-		writers = append(writers, s.fileWriter)
+		s.pruneOnInit(s.fileWriter.Filename)
+		few := newWriteErrorWriter(s.fileWriter, s)
+		few.fallback = s.stderrFallback(consoleLogging)
+		var fw io.Writer = few
+		if s.Config.FlushIntervalMS > 0 {
+			s.bufWriter = newBufferedWriter(fw, time.Duration(s.Config.FlushIntervalMS)*time.Millisecond, &s.bufferedDrops)
+			fw = s.bufWriter
+		}
+		if s.Config.FileConsoleFormat {
+			fcw := zerolog.ConsoleWriter{Out: fw, NoColor: true}
+			if s.LoggingConfig.ConsoleTimeFormat != "" {
+				fcw.TimeFormat = s.LoggingConfig.ConsoleTimeFormat
+			}
+			fw = fcw
+		}
+		writers = append(writers, fw)
+	}
+	if s.Config.ErrorFileEnabled {
+		s.errFileWriter = s.newRollingFileLogger(errorLogFileName)
+		s.pruneOnInit(s.errFileWriter.Filename)
+		eew := newWriteErrorWriter(s.errFileWriter, s)
+		eew.fallback = s.stderrFallback(consoleLogging)
+		var ew io.Writer = eew
+		if s.Config.FileConsoleFormat {
+			ew = zerolog.ConsoleWriter{Out: ew, NoColor: true, TimeFormat: s.LoggingConfig.ConsoleTimeFormat}
+		}
+		writers = append(writers, newLevelRangeWriter(ew, zerolog.ErrorLevel, zerolog.PanicLevel))
 	}
 	if consoleLogging {
-		cw := zerolog.ConsoleWriter{Out: os.Stderr}
+		cw := zerolog.ConsoleWriter{Out: s.stderrOut()}
 		if s.LoggingConfig.ConsoleNoColor {
 			cw.NoColor = true
 		}
 		if s.LoggingConfig.ConsoleTimeFormat != "" {
 			cw.TimeFormat = s.LoggingConfig.ConsoleTimeFormat
 		}
-		writers = append(writers, cw)
+		if s.Config.ConsoleMinLevel != emptyString {
+			// Validated by validateLocalConfig; NoLevel keeps audit events
+			minLevel, _ := parseLevel(s.Config.ConsoleMinLevel)
+			writers = append(writers, newLevelRangeWriter(cw, minLevel, zerolog.NoLevel))
+		} else {
+			writers = append(writers, cw)
+		}
+	}
+
+	if s.Config.FifoPath != emptyString {
+		fifo, err := newFifoWriter(s.fifoPath(), &s.fifoDrops)
+		if err != nil {
+			return nil, errors.New(op).Errorf("newFifoWriter: %w", err)
+		}
+		s.fifoWriter = fifo
+		writers = append(writers, fifo)
 	}
 
-	return writers
+	return writers, nil
 }
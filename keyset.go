@@ -0,0 +1,35 @@
+package logging
+
+import "sync"
+
+// keySet is a concurrency-safe set of strings used to deduplicate one-time log
+// lines (Once, Deprecated, NotImplemented).
+type keySet struct {
+	m sync.Map
+}
+
+// add records key and reports whether it was not already present.
+func (k *keySet) add(key string) bool {
+	_, loaded := k.m.LoadOrStore(key, struct{}{})
+	return !loaded
+}
+
+// has reports whether key is present.
+func (k *keySet) has(key string) bool {
+	_, ok := k.m.Load(key)
+	return ok
+}
+
+// reset removes all keys.
+func (k *keySet) reset() {
+	k.m.Clear()
+}
+
+// claimOnce makes event drop its line unless it is the first to add key to
+// keys when it is written.
+func claimOnce(event LogEvent, keys *keySet, key string) LogEvent {
+	if t, ok := event.(*trackedLogEvent); ok {
+		t.claim = func() bool { return keys.add(key) }
+	}
+	return event
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// kvErrorFieldName holds the problems found in the arguments of the *KV methods.
+const kvErrorFieldName = "_kv_error"
+
+// DebugKV logs msg at Debug level with kv as alternating keys and values; see InfoKV.
+func (s *Service) DebugKV(msg string, kv ...interface{}) {
+	addKV(s.DebugWith(), kv).Msg(msg)
+}
+
+// InfoKV logs msg at Info level with kv as alternating keys and values, for
+// migrating printf-style code: svc.InfoKV("done", "user", "u1", "count", 3).
+// Keys must be strings. Values keep their type: strings, numbers, bools, times,
+// durations and errors map to the matching field type, and anything else is
+// serialized like Interface. A missing value or a non-string key does not
+// panic: the pair is skipped and described in a _kv_error field.
+func (s *Service) InfoKV(msg string, kv ...interface{}) {
+	addKV(s.InfoWith(), kv).Msg(msg)
+}
+
+// WarnKV logs msg at Warn level with kv as alternating keys and values; see InfoKV.
+func (s *Service) WarnKV(msg string, kv ...interface{}) {
+	addKV(s.WarnWith(), kv).Msg(msg)
+}
+
+// ErrorKV logs msg at Error level with kv as alternating keys and values; see
+// InfoKV. Error values get the usual chain enrichment under their key.
+func (s *Service) ErrorKV(msg string, kv ...interface{}) {
+	addKV(s.ErrorWith(), kv).Msg(msg)
+}
+
+// addKV adds the key/value pairs in kv to event.
+func addKV(event LogEvent, kv []interface{}) LogEvent {
+	if len(kv) == 0 {
+		return event
+	}
+	var problems []string
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("key at position %d is a %T, not a string", i, kv[i]))
+			continue
+		}
+		if i+1 == len(kv) {
+			problems = append(problems, fmt.Sprintf("missing value for key %q", key))
+			break
+		}
+		event = addKVField(event, key, kv[i+1])
+	}
+	if len(problems) > 0 {
+		event = event.Str(kvErrorFieldName, strings.Join(problems, "; "))
+	}
+	return event
+}
+
+// addKVField adds val under key with the field type matching its dynamic type.
+// Other types, including named types such as `type Channel int`, go through
+// Interface so that registered dumpers and json.Marshaler implementations apply.
+func addKVField(event LogEvent, key string, val interface{}) LogEvent {
+	switch v := val.(type) {
+	case string:
+		return event.Str(key, v)
+	case bool:
+		return event.Bool(key, v)
+	case int:
+		return event.Int(key, v)
+	case int8:
+		return event.Int64(key, int64(v))
+	case int16:
+		return event.Int64(key, int64(v))
+	case int32:
+		return event.Int32(key, v)
+	case int64:
+		return event.Int64(key, v)
+	case uint:
+		return event.Uint(key, v)
+	case uint8:
+		return event.Uint64(key, uint64(v))
+	case uint16:
+		return event.Uint64(key, uint64(v))
+	case uint32:
+		return event.Uint64(key, uint64(v))
+	case uint64:
+		return event.Uint64(key, v)
+	case float32:
+		return event.Float32(key, v)
+	case float64:
+		return event.Float64(key, v)
+	case time.Time:
+		return event.Time(key, v)
+	case time.Duration:
+		return event.Dur(key, v)
+	case error:
+		return event.AnErr(key, v)
+	default:
+		return event.Interface(key, val)
+	}
+}
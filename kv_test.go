@@ -0,0 +1,62 @@
+package logging
+
+import (
+	stderrs "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type kvChannel int
+
+func TestService_InfoKV(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.InfoKV("done", "user", "u1", "count", 3)
+	service.DebugKV("typed",
+		"ok", true, "ratio", 0.5, "small", uint8(7), "took", 1500*time.Millisecond,
+		"channel", kvChannel(4), "tags", []string{"a", "b"}, "none", nil)
+	service.WarnKV("odd", "user", "u1", "dangling")
+	service.ErrorKV("failed", 42, "bad key", "err", stderrs.New("disk full"))
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 4)
+
+	done := entries[0]
+	assert.Equal(t, "info", done["level"])
+	assert.Equal(t, "done", done["message"])
+	assert.Equal(t, "u1", done["user"])
+	assert.Equal(t, float64(3), done["count"])
+	assert.NotContains(t, done, kvErrorFieldName)
+
+	typed := entries[1]
+	assert.Equal(t, true, typed["ok"])
+	assert.Equal(t, 0.5, typed["ratio"])
+	assert.Equal(t, float64(7), typed["small"])
+	assert.Equal(t, float64(1500), typed["took"])
+	assert.Equal(t, float64(4), typed["channel"])
+	assert.Equal(t, []any{"a", "b"}, typed["tags"])
+	assert.Contains(t, typed, "none")
+	assert.Nil(t, typed["none"])
+
+	odd := entries[2]
+	assert.Equal(t, "warn", odd["level"])
+	assert.Equal(t, "u1", odd["user"])
+	assert.Equal(t, `missing value for key "dangling"`, odd[kvErrorFieldName])
+
+	failed := entries[3]
+	assert.Equal(t, "key at position 0 is a int, not a string", failed[kvErrorFieldName])
+	assert.Equal(t, "disk full", failed["err"])
+	assert.Equal(t, "disk full", failed["err_root"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestService_InfoKV_Uninitialized(t *testing.T) {
+	var nilService *Service
+	assert.NotPanics(t, func() {
+		nilService.InfoKV("x", "k")
+		(&Service{}).ErrorKV("x", "k", 1)
+	})
+}
@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/Station-Manager/errors"
+	"github.com/rs/zerolog"
+)
+
+var (
+	levelAliasMu sync.RWMutex
+	// levelAliases maps lower-case alias names to canonical zerolog level names.
+	levelAliases = map[string]string{
+		"warning":  "warn",
+		"critical": "error",
+		"trace":    "trace",
+	}
+)
+
+// RegisterLevelAlias makes alias (case-insensitive) accepted wherever a level
+// name is, e.g. LoggingConfig.Level, Config.ConsoleMinLevel or SM_LOG_LEVEL,
+// as a synonym for canonical. canonical must be a zerolog level name; aliases
+// of aliases are not supported. The aliases "warning" (warn), "critical"
+// (error) and "trace" are registered by default. Registration applies to the
+// whole process and should happen before services are initialized.
+func RegisterLevelAlias(alias, canonical string) error {
+	const op errors.Op = "logging.RegisterLevelAlias"
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if alias == emptyString {
+		return errors.New(op).Msg("alias cannot be empty")
+	}
+	level, err := zerolog.ParseLevel(canonical)
+	if err != nil || level == zerolog.NoLevel {
+		return errors.New(op).Msgf("canonical level '%s' is not a valid level", canonical)
+	}
+
+	levelAliasMu.Lock()
+	defer levelAliasMu.Unlock()
+	levelAliases[alias] = level.String()
+	return nil
+}
+
+// canonicalLevel returns the level name that level is an alias of, or level
+// itself if it is not a registered alias.
+func canonicalLevel(level string) string {
+	levelAliasMu.RLock()
+	defer levelAliasMu.RUnlock()
+	if canonical, ok := levelAliases[strings.ToLower(level)]; ok {
+		return canonical
+	}
+	return level
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel_Aliases(t *testing.T) {
+	tests := []struct {
+		in   string
+		want zerolog.Level
+	}{
+		{"warning", zerolog.WarnLevel},
+		{"WARNING", zerolog.WarnLevel},
+		{"critical", zerolog.ErrorLevel},
+		{"trace", zerolog.TraceLevel},
+		{"warn", zerolog.WarnLevel},
+		{"info", zerolog.InfoLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseLevel(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := parseLevel("chatty")
+	assert.Error(t, err)
+}
+
+func TestRegisterLevelAlias(t *testing.T) {
+	t.Cleanup(func() {
+		levelAliasMu.Lock()
+		delete(levelAliases, "verbose")
+		levelAliasMu.Unlock()
+	})
+
+	require.NoError(t, RegisterLevelAlias("Verbose", "debug"))
+	got, err := parseLevel("verbose")
+	require.NoError(t, err)
+	assert.Equal(t, zerolog.DebugLevel, got)
+
+	assert.Error(t, RegisterLevelAlias("", "debug"))
+	assert.Error(t, RegisterLevelAlias("loud", "warning"), "aliases of aliases are rejected")
+	assert.Error(t, RegisterLevelAlias("loud", "nope"))
+}
+
+func TestValidateConfig_LevelAlias(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.Level = "warning"
+	require.NoError(t, validateConfig(cfg))
+	assert.Equal(t, "warn", cfg.Level)
+
+	cfg = validLoggingConfig()
+	cfg.Level = "warning"
+	service, dir := newFileTestService(t, cfg, Config{ConsoleMinLevel: "critical"})
+	assert.Equal(t, "warn", service.LoggingConfig.Level)
+	service.InfoWith().Msg("dropped")
+	service.WarnWith().Msg("kept")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "kept", entries[0]["message"])
+}
@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+// levelRangeWriter forwards only events whose level lies within [min, max].
+// Writes without level information are forwarded unchanged.
+type levelRangeWriter struct {
+	w        io.Writer
+	min, max zerolog.Level
+}
+
+// newLevelRangeWriter wraps w so that it only receives events in [min, max].
+func newLevelRangeWriter(w io.Writer, min, max zerolog.Level) *levelRangeWriter {
+	return &levelRangeWriter{w: w, min: min, max: max}
+}
+
+// Write implements io.Writer.
+func (lw *levelRangeWriter) Write(p []byte) (int, error) {
+	return lw.w.Write(p)
+}
+
+// WriteLevel implements zerolog.LevelWriter. Events outside the range are
+// reported as written so that the multi-writer does not flag an error.
+func (lw *levelRangeWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < lw.min || level > lw.max {
+		return len(p), nil
+	}
+	if w, ok := lw.w.(zerolog.LevelWriter); ok {
+		return w.WriteLevel(level, p)
+	}
+	return lw.w.Write(p)
+}
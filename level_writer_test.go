@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorFileEnabled(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{ErrorFileEnabled: true})
+	require.NotNil(t, service.errFileWriter)
+	mainFile := logFileName(service)
+
+	service.InfoWith().Msg("info line")
+	service.ErrorWith().Err(assert.AnError).Msg("error line")
+	service.AuditWith().Msg("audit line")
+
+	main := readLogEntries(t, dir, mainFile)
+	require.Len(t, main, 3)
+	assert.Equal(t, "info line", main[0]["message"])
+	assert.Equal(t, "error line", main[1]["message"])
+
+	errs := readLogEntries(t, dir, errorLogFileName)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "error line", errs[0]["message"])
+	assert.Equal(t, "error", errs[0]["level"])
+
+	require.NoError(t, service.Close())
+	assert.Nil(t, service.errFileWriter)
+}
+
+func TestConsoleMinLevel(t *testing.T) {
+	var console threadSafeBuffer
+	cfg := validLoggingConfig()
+	cfg.Level = "debug"
+	cfg.ConsoleLogging = true
+	cfg.FileLogging = true
+	cfg.ConsoleNoColor = true
+
+	tmpDir := t.TempDir()
+	service := &Service{
+		WorkingDir:    tmpDir,
+		ConfigService: newTestConfigService(cfg),
+		Config:        Config{ConsoleMinLevel: "info"},
+		stderr:        &console,
+	}
+	require.NoError(t, service.Initialize())
+	t.Cleanup(func() { _ = service.Close() })
+
+	service.DebugWith().Msg("debug detail")
+	service.InfoWith().Msg("info summary")
+	service.AuditWith().Msg("audit record")
+
+	entries := readLogEntries(t, filepath.Join(tmpDir, cfg.RelLogFileDir), logFileName(service))
+	require.Len(t, entries, 3)
+	assert.Equal(t, "debug detail", entries[0]["message"])
+
+	out := console.String()
+	assert.NotContains(t, out, "debug detail")
+	assert.Contains(t, out, "info summary")
+	assert.Contains(t, out, "audit record")
+}
@@ -0,0 +1,78 @@
+// Package logtest provides helpers for asserting on structured (JSON) log
+// output captured from a logging.Service in tests.
+//
+// Typical usage
+//
+//	var buf bytes.Buffer
+//	_ = svc.SetOutput(&buf)
+//	svc.InfoWith().Str("user_id", "42").Msg("processed")
+//
+//	logtest.AssertField(t, buf.Bytes(), "user_id", "42")
+//	lines, err := logtest.DecodeLines(&buf) // for output with several lines
+package logtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// DecodeLines decodes every JSON object in r (one per line, as written by the
+// logger) into a map. Numbers decode as float64, as with encoding/json.
+func DecodeLines(r io.Reader) ([]map[string]any, error) {
+	if r == nil {
+		return nil, fmt.Errorf("logtest: reader is nil")
+	}
+	var lines []map[string]any
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var line map[string]any
+		if err := dec.Decode(&line); err != nil {
+			return lines, fmt.Errorf("logtest: decode line %d: %w", len(lines)+1, err)
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// AssertField reports a test error unless the JSON log line has the field key
+// equal to want. want is compared after a JSON round trip, so Go values such as
+// int(5), []string or structs match their decoded form. Passing nil as want
+// asserts that the field is present with a null value.
+func AssertField(t testing.TB, line []byte, key string, want any) {
+	t.Helper()
+	var fields map[string]any
+	if err := json.Unmarshal(bytes.TrimSpace(line), &fields); err != nil {
+		t.Errorf("logtest: line is not a JSON object: %v\nline: %s", err, line)
+		return
+	}
+	got, ok := fields[key]
+	if !ok {
+		t.Errorf("logtest: field %q is missing\nline: %s", key, line)
+		return
+	}
+	normalized, err := normalize(want)
+	if err != nil {
+		t.Errorf("logtest: cannot compare field %q: %v", key, err)
+		return
+	}
+	if !reflect.DeepEqual(got, normalized) {
+		t.Errorf("logtest: field %q = %#v, want %#v\nline: %s", key, got, normalized, line)
+	}
+}
+
+// normalize converts v into the form encoding/json decodes it to.
+func normalize(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,80 @@
+package logtest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTB captures the failures reported by the helpers under test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+const sampleLine = `{"level":"info","user_id":"42","count":5,"tags":["a","b"],"cause":null,"message":"processed"}` + "\n"
+
+func TestAssertField_Present(t *testing.T) {
+	rec := &recordingTB{}
+	AssertField(rec, []byte(sampleLine), "user_id", "42")
+	AssertField(rec, []byte(sampleLine), "count", 5)
+	AssertField(rec, []byte(sampleLine), "tags", []string{"a", "b"})
+	AssertField(rec, []byte(sampleLine), "cause", nil)
+	assert.Empty(t, rec.errors)
+}
+
+func TestAssertField_Absent(t *testing.T) {
+	rec := &recordingTB{}
+	AssertField(rec, []byte(sampleLine), "request_id", "abc")
+	require.Len(t, rec.errors, 1)
+	assert.Contains(t, rec.errors[0], `field "request_id" is missing`)
+}
+
+func TestAssertField_Mismatch(t *testing.T) {
+	rec := &recordingTB{}
+	AssertField(rec, []byte(sampleLine), "user_id", "43")
+	AssertField(rec, []byte(sampleLine), "count", "5")
+	require.Len(t, rec.errors, 2)
+	assert.Contains(t, rec.errors[0], `field "user_id" = "42", want "43"`)
+	assert.Contains(t, rec.errors[1], `field "count"`)
+}
+
+func TestAssertField_NotJSON(t *testing.T) {
+	rec := &recordingTB{}
+	AssertField(rec, []byte("plain text"), "user_id", "42")
+	require.Len(t, rec.errors, 1)
+	assert.Contains(t, rec.errors[0], "not a JSON object")
+}
+
+func TestDecodeLines(t *testing.T) {
+	lines, err := DecodeLines(strings.NewReader(sampleLine + `{"level":"warn","message":"second"}` + "\n"))
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "42", lines[0]["user_id"])
+	assert.Equal(t, float64(5), lines[0]["count"])
+	assert.Equal(t, "second", lines[1]["message"])
+
+	lines, err = DecodeLines(&bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Empty(t, lines)
+}
+
+func TestDecodeLines_Invalid(t *testing.T) {
+	lines, err := DecodeLines(strings.NewReader(sampleLine + "not json\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "decode line 2")
+	assert.Len(t, lines, 1)
+
+	_, err = DecodeLines(nil)
+	assert.Error(t, err)
+}
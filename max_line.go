@@ -0,0 +1,221 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// minMaxLineBytes is the smallest accepted Config.MaxLineBytes; it leaves room
+	// for the level, timestamp and truncation markers.
+	minMaxLineBytes = 128
+
+	truncatedFieldName     = "_truncated"
+	originalBytesFieldName = "_original_bytes"
+	truncationSuffix       = "..."
+)
+
+// maxLineWriter caps the size of each written line. Oversized JSON lines are
+// shrunk by truncating their longest string values, then by dropping their
+// largest fields (keeping time, level and message), and are marked with
+// _truncated and _original_bytes. Other lines are cut at the cap.
+type maxLineWriter struct {
+	w   zerolog.LevelWriter
+	max int
+}
+
+func newMaxLineWriter(w zerolog.LevelWriter, max int) *maxLineWriter {
+	return &maxLineWriter{w: w, max: max}
+}
+
+// Write implements io.Writer.
+func (mw *maxLineWriter) Write(p []byte) (int, error) {
+	return mw.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+func (mw *maxLineWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if len(p) <= mw.max {
+		return mw.w.WriteLevel(level, p)
+	}
+	out, ok := truncateJSONLine(p, mw.max)
+	if !ok {
+		out = truncateRawLine(p, mw.max)
+	}
+	if _, err := mw.w.WriteLevel(level, out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// jsonField is a top-level key and its raw JSON value.
+type jsonField struct {
+	key string
+	raw json.RawMessage
+}
+
+// splitJSONObject returns the top-level fields of p in order, or false if p is
+// not a single JSON object.
+func splitJSONObject(p []byte) ([]jsonField, bool) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, false
+	}
+	var fields []jsonField
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, false
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, false
+		}
+		fields = append(fields, jsonField{key: key, raw: raw})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, false
+	}
+	return fields, true
+}
+
+// truncateJSONLine shrinks the JSON object in p to at most max bytes and adds
+// the truncation markers, or returns false if p is not a JSON object.
+func truncateJSONLine(p []byte, max int) ([]byte, bool) {
+	fields, ok := splitJSONObject(p)
+	if !ok {
+		return nil, false
+	}
+	marker := `,"` + truncatedFieldName + `":true,"` + originalBytesFieldName + `":` + strconv.Itoa(len(p))
+	budget := max - len(marker) - 1 // closing newline
+
+	// Longest string values first
+	order := make([]int, len(fields))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return len(fields[order[a]].raw) > len(fields[order[b]].raw) })
+	for _, i := range order {
+		excess := encodedObjectLen(fields) - budget
+		if excess <= 0 {
+			break
+		}
+		if k := fields[i].key; k == zerolog.TimestampFieldName || k == zerolog.LevelFieldName {
+			continue
+		}
+		if raw, ok := truncateJSONString(fields[i].raw, excess); ok {
+			fields[i].raw = raw
+		}
+	}
+
+	// Then drop the largest fields that are not essential
+	for _, i := range order {
+		if encodedObjectLen(fields) <= budget {
+			break
+		}
+		switch fields[i].key {
+		case zerolog.TimestampFieldName, zerolog.LevelFieldName, zerolog.MessageFieldName:
+			continue
+		}
+		fields[i].raw = nil
+	}
+
+	out := make([]byte, 0, max)
+	out = append(out, '{')
+	for _, f := range fields {
+		if f.raw == nil {
+			continue
+		}
+		if len(out) > 1 {
+			out = append(out, ',')
+		}
+		out = append(out, marshalNoEscapeHTML(f.key)...)
+		out = append(out, ':')
+		out = append(out, f.raw...)
+	}
+	if len(out) == 1 {
+		marker = marker[1:]
+	}
+	out = append(out, marker...)
+	out = append(out, '}', '\n')
+	return out, true
+}
+
+// encodedObjectLen returns the size of fields encoded as a JSON object, skipping
+// dropped (nil) values.
+func encodedObjectLen(fields []jsonField) int {
+	n := 2
+	first := true
+	for _, f := range fields {
+		if f.raw == nil {
+			continue
+		}
+		if !first {
+			n++
+		}
+		first = false
+		n += len(marshalNoEscapeHTML(f.key)) + 1 + len(f.raw)
+	}
+	return n
+}
+
+// truncateJSONString shortens the JSON string raw by at least excess bytes,
+// appending truncationSuffix. It returns false if raw is not a string or
+// cannot be shortened further.
+func truncateJSONString(raw json.RawMessage, excess int) (json.RawMessage, bool) {
+	if len(raw) == 0 || raw[0] != '"' {
+		return nil, false
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, false
+	}
+	target := len(raw) - excess
+	keep := len(s) - excess - len(truncationSuffix)
+	for {
+		if keep < 0 {
+			keep = 0
+		}
+		for keep > 0 && keep < len(s) && !utf8.RuneStart(s[keep]) {
+			keep--
+		}
+		out := marshalNoEscapeHTML(s[:keep] + truncationSuffix)
+		if len(out) <= target || keep == 0 {
+			if len(out) >= len(raw) {
+				return nil, false
+			}
+			return out, true
+		}
+		keep -= len(out) - target
+	}
+}
+
+// marshalNoEscapeHTML encodes s as a JSON string without HTML escaping, like zerolog.
+func marshalNoEscapeHTML(s string) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'})
+}
+
+// truncateRawLine cuts a non-JSON line to max bytes, including the newline,
+// without splitting a UTF-8 sequence.
+func truncateRawLine(p []byte, max int) []byte {
+	keep := max - 1
+	for keep > 0 && !utf8.RuneStart(p[keep]) {
+		keep--
+	}
+	out := make([]byte, 0, keep+1)
+	out = append(out, p[:keep]...)
+	return append(out, '\n')
+}
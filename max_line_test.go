@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxLineBytes(t *testing.T) {
+	const maxLine = 1024
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{MaxLineBytes: maxLine})
+
+	payload := strings.Repeat("x", 4*maxLine)
+	service.InfoWith().Str("request_id", "r1").Str("payload", payload).Msg("big")
+	service.InfoWith().Str("request_id", "r2").Msg("small")
+
+	f, err := os.Open(filepath.Join(dir, logFileName(service)))
+	require.NoError(t, err)
+	defer f.Close()
+	var lines []string
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	require.Len(t, lines, 2)
+	assert.LessOrEqual(t, len(lines[0])+1, maxLine)
+
+	var big, small map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &big))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &small))
+
+	assert.Equal(t, true, big["_truncated"])
+	assert.Greater(t, big["_original_bytes"], float64(4*maxLine))
+	assert.Equal(t, "big", big["message"])
+	assert.Equal(t, "r1", big["request_id"])
+	assert.True(t, strings.HasSuffix(big["payload"].(string), truncationSuffix))
+
+	assert.NotContains(t, small, "_truncated")
+	assert.Equal(t, "r2", small["request_id"])
+}
+
+func TestTruncateJSONLine_DropsNonStringFields(t *testing.T) {
+	values := make([]int, 200)
+	line, err := json.Marshal(map[string]interface{}{
+		"level":   "info",
+		"message": "m",
+		"values":  values,
+	})
+	require.NoError(t, err)
+	line = append(line, '\n')
+
+	out, ok := truncateJSONLine(line, minMaxLineBytes)
+	require.True(t, ok)
+	assert.LessOrEqual(t, len(out), minMaxLineBytes)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &entry))
+	assert.NotContains(t, entry, "values")
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, true, entry["_truncated"])
+	assert.Equal(t, float64(len(line)), entry["_original_bytes"])
+}
+
+func TestTruncateJSONString_UTF8(t *testing.T) {
+	raw, err := json.Marshal(strings.Repeat("é", 100))
+	require.NoError(t, err)
+
+	out, ok := truncateJSONString(raw, 51)
+	require.True(t, ok)
+	assert.LessOrEqual(t, len(out), len(raw)-51)
+
+	var s string
+	require.NoError(t, json.Unmarshal(out, &s))
+	assert.True(t, strings.HasSuffix(s, truncationSuffix))
+	assert.True(t, strings.HasPrefix(s, "é"))
+}
+
+func TestMaxLineWriter_NonJSON(t *testing.T) {
+	var buf threadSafeBuffer
+	w := newMaxLineWriter(zerolog.MultiLevelWriter(&buf), minMaxLineBytes)
+
+	n, err := w.Write([]byte(strings.Repeat("a", 300) + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 301, n)
+	assert.Len(t, buf.String(), minMaxLineBytes)
+	assert.True(t, strings.HasSuffix(buf.String(), "\n"))
+}
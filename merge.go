@@ -0,0 +1,42 @@
+package logging
+
+// Merge returns a context logger carrying the fields of all given loggers, in
+// order, e.g. to layer a sub-operation logger's fields onto a request logger.
+// When a key occurs more than once the later logger's value wins, keeping the
+// position of its first occurrence. Only context loggers of this service (from
+// With, WithComponent, WithDict, CtxLogger and the like) contribute fields;
+// other loggers are ignored. A CtxLogger context or WithDeadline margin is taken
+// from the last logger that carries one. Returns a no-op logger if the service
+// is not initialized.
+// Example: log := svc.Merge(reqLogger, dbLogger)
+func (s *Service) Merge(loggers ...Logger) Logger {
+	merged, ok := s.With().(*logContext)
+	if !ok {
+		return &noopLogger{}
+	}
+
+	var fields []contextField
+	index := make(map[string]int)
+	for _, l := range loggers {
+		cl, ok := l.(*contextLogger)
+		if !ok || cl.parent != s {
+			continue
+		}
+		if cl.ctx != nil {
+			merged.ctx = cl.ctx
+			merged.margin = cl.margin
+		}
+		for _, f := range cl.fields {
+			if i, seen := index[f.key]; seen {
+				fields[i] = f
+				continue
+			}
+			index[f.key] = len(fields)
+			fields = append(fields, f)
+		}
+	}
+	for _, f := range fields {
+		merged.add(f.key, f.apply)
+	}
+	return merged.Logger()
+}
@@ -0,0 +1,58 @@
+package logging
+
+import (
+	stderrs "errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Merge(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	request := service.With().Str("request_id", "r1").Str("stage", "request").Int("attempt", 1).Logger()
+	dbOp := request.With().Str("table", "contacts").Str("stage", "db").Logger()
+	retry := service.With().Int("attempt", 2).Err(stderrs.New("timeout")).Logger()
+
+	merged := service.Merge(request, dbOp, retry, &noopLogger{})
+	merged.InfoWith().Msg("merged")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "r1", entry["request_id"])
+	assert.Equal(t, "contacts", entry["table"])
+	assert.Equal(t, "db", entry["stage"])
+	assert.Equal(t, float64(2), entry["attempt"])
+	assert.Equal(t, "timeout", entry["error"])
+	assert.Equal(t, "timeout", entry["error_root"])
+
+	// Each key is written once, although dbOp inherits stage from request
+	raw, err := os.ReadFile(filepath.Join(dir, logFileName(service)))
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(raw), `"stage":`))
+	assert.Equal(t, 1, strings.Count(string(raw), `"attempt":`))
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestService_Merge_FieldsAddedLaterDoNotLeak(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	ctx := service.With().Str("a", "1")
+	first := ctx.Logger()
+	ctx.Str("b", "2")
+
+	service.Merge(first).InfoWith().Msg("merged")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "1", entries[0]["a"])
+	assert.NotContains(t, entries[0], "b")
+
+	var nilService *Service
+	assert.IsType(t, &noopLogger{}, nilService.Merge(first))
+}
@@ -0,0 +1,28 @@
+package logging
+
+import (
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+)
+
+// NewWithConfig creates and initializes a Service directly from cfg, without a
+// ConfigService. It is meant for embedding scenarios where only a
+// types.LoggingConfig is available. cfg is copied, validated and used for the
+// same writer setup as Initialize; environment overrides are not applied and
+// the package-local Config keeps its zero value. An empty workingDir defaults
+// to the executable's directory. The returned Service must be closed.
+func NewWithConfig(workingDir string, cfg *types.LoggingConfig) (*Service, error) {
+	const op errors.Op = "logging.NewWithConfig"
+	if cfg == nil {
+		return nil, errors.New(op).Msg(errMsgNilConfig)
+	}
+
+	s := &Service{WorkingDir: workingDir}
+	s.initOnce.Do(func() {
+		s.initErr = s.initialize(*cfg, initOptions{})
+	})
+	if s.initErr != nil {
+		return nil, errors.New(op).Err(s.initErr).Msg("failed to initialize service")
+	}
+	return s, nil
+}
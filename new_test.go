@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &types.LoggingConfig{
+		Level:             "info",
+		WithTimestamp:     true,
+		FileLogging:       true,
+		RelLogFileDir:     "logs",
+		LogFileMaxBackups: 1,
+		LogFileMaxAgeDays: 1,
+		LogFileMaxSizeMB:  1,
+	}
+
+	service, err := NewWithConfig(dir, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, service)
+	assert.Nil(t, service.ConfigService)
+	assert.NotSame(t, cfg, service.LoggingConfig)
+
+	fileName := logFileName(service)
+
+	service.InfoWith().Str("mode", "embedded").Msg("hello")
+	service.DebugWith().Msg("filtered")
+	require.NoError(t, service.Close())
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	entries := readLogEntries(t, filepath.Join(dir, "logs"), fileName)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "hello", entries[0]["message"])
+	assert.Equal(t, "embedded", entries[0]["mode"])
+}
+
+func TestNewWithConfig_Errors(t *testing.T) {
+	_, err := NewWithConfig(t.TempDir(), nil)
+	require.Error(t, err)
+
+	cfg := validLoggingConfig()
+	cfg.Level = "loud"
+	_, err = NewWithConfig(t.TempDir(), cfg)
+	require.Error(t, err)
+}
@@ -0,0 +1,25 @@
+package logging
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// NotImplemented emits a Warn line with not_implemented=true, the feature name
+// and the caller's file:line as stub_caller the first time it is called for a
+// given feature; later calls for the same feature are ignored. A feature only
+// counts as reported once its line was actually written. Use it to mark
+// unfinished code paths so that they show up when exercised.
+// Example: svc.NotImplemented("export.adif")
+func (s *Service) NotImplemented(feature string) {
+	if s == nil || !s.isInitialized.Load() || s.stubFeatures.has(feature) {
+		return
+	}
+	event := claimOnce(s.WarnWith(), &s.stubFeatures, feature).
+		Bool("not_implemented", true).
+		Str("feature", feature)
+	if _, file, line, ok := runtime.Caller(1); ok {
+		event = event.Str("stub_caller", fmt.Sprintf("%s:%d", file, line))
+	}
+	event.Msg("not implemented")
+}
@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_NotImplemented(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	for i := 0; i < 3; i++ {
+		service.NotImplemented("export.adif")
+	}
+	service.NotImplemented("import.cabrillo")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "warn", entries[0]["level"])
+	assert.Equal(t, true, entries[0]["not_implemented"])
+	assert.Equal(t, "export.adif", entries[0]["feature"])
+	assert.Contains(t, entries[0]["stub_caller"], "not_implemented_test.go:")
+	assert.Equal(t, "import.cabrillo", entries[1]["feature"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	var nilService *Service
+	nilService.NotImplemented("x")
+}
+
+func TestService_NotImplemented_MarkedOnlyWhenWritten(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	// A dropped line does not count as reported
+	service.SetFilter(func(zerolog.Level, string) bool { return false })
+	service.NotImplemented("export.adif")
+	service.SetFilter(nil)
+	service.NotImplemented("export.adif")
+	service.NotImplemented("export.adif")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "export.adif", entries[0]["feature"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
@@ -0,0 +1,33 @@
+package logging
+
+import (
+	"sort"
+
+	"github.com/rs/zerolog"
+)
+
+// Observe writes a metric observation as a Debug line with metric=true,
+// metric_name, metric_value and one string field per label (in key order), so
+// that a log scraper can turn it into a histogram or gauge sample. Like any
+// Debug line it is dropped when the level is above debug.
+// Example: svc.Observe("http_request_seconds", elapsed.Seconds(), map[string]string{"route": "/v1/items"})
+func (s *Service) Observe(name string, value float64, labels map[string]string) {
+	e := logEventBuilder(s, zerolog.DebugLevel)
+	if e == noopEvent {
+		return
+	}
+	e.Bool("metric", true).
+		Str("metric_name", name).
+		Float64("metric_value", value)
+	if len(labels) > 0 {
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			e.Str(k, labels[k])
+		}
+	}
+	e.Msg("metric")
+}
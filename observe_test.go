@@ -0,0 +1,43 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserve(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.Observe("request_seconds", 0.25, map[string]string{"route": "/v1/items", "method": "GET"})
+	service.Observe("queue_depth", 3, nil)
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+
+	first := entries[0]
+	assert.Equal(t, "debug", first["level"])
+	assert.Equal(t, true, first["metric"])
+	assert.Equal(t, "request_seconds", first["metric_name"])
+	assert.Equal(t, 0.25, first["metric_value"])
+	assert.Equal(t, "/v1/items", first["route"])
+	assert.Equal(t, "GET", first["method"])
+
+	assert.Equal(t, "queue_depth", entries[1]["metric_name"])
+	assert.Equal(t, float64(3), entries[1]["metric_value"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestObserve_DisabledBelowDebug(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.Level = "info"
+	service, dir := newFileTestService(t, cfg, Config{})
+
+	service.Observe("request_seconds", 1, map[string]string{"route": "/"})
+	service.InfoWith().Msg("marker")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "marker", entries[0]["message"])
+}
@@ -0,0 +1,22 @@
+package logging
+
+// Once returns an Info-level LogEvent that is written only the first time it is
+// finished for a given key, so that lines such as a version banner are written
+// a single time even if reached from several code paths. The key counts as
+// logged once a line was actually written: an event that is disabled, filtered
+// or sampled out leaves it unmarked.
+// Example: svc.Once("banner").Str("version", version).Msg("starting")
+func (s *Service) Once(key string) LogEvent {
+	if s == nil || !s.isInitialized.Load() || s.onceKeys.has(key) {
+		return newLogEvent(nil)
+	}
+	return claimOnce(s.InfoWith(), &s.onceKeys, key)
+}
+
+// ResetOnce forgets the keys seen by Once so that they log again. Intended for tests.
+func (s *Service) ResetOnce() {
+	if s == nil {
+		return
+	}
+	s.onceKeys.reset()
+}
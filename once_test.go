@@ -0,0 +1,54 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnce(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.Once("banner").Str("version", "1.2.3").Msg("starting")
+	service.Once("banner").Str("version", "1.2.3").Msg("starting")
+	service.Once("config").Msg("config summary")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "starting", entries[0]["message"])
+	assert.Equal(t, "1.2.3", entries[0]["version"])
+	assert.Equal(t, "config summary", entries[1]["message"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	service.ResetOnce()
+	service.Once("banner").Msg("starting")
+	assert.Len(t, readLogEntries(t, dir, logFileName(service)), 3)
+}
+
+func TestOnce_Uninitialized(t *testing.T) {
+	var nilService *Service
+	nilService.Once("banner").Msg("no panic")
+	nilService.ResetOnce()
+}
+
+func TestOnce_KeyMarkedOnlyWhenWritten(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	// A line dropped by the filter does not use up the key
+	service.SetFilter(func(_ zerolog.Level, msg string) bool { return msg != "dropped" })
+	service.Once("banner").Msg("dropped")
+	service.Once("banner").Msgf("starting %s", "v1")
+
+	// Two events taken before either is written: only the first to finish logs
+	first, second := service.Once("config"), service.Once("config")
+	second.Send()
+	first.Msg("config summary")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "starting v1", entries[0]["message"])
+	assert.NotContains(t, entries[1], "message")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"io"
+	"time"
+)
+
+// Option customizes a single Initialize call, mainly to make tests independent
+// of the file system and the wall clock.
+type Option func(*initOptions)
+
+// initOptions holds the settings applied by Options.
+type initOptions struct {
+	writer io.Writer
+	clock  func() time.Time
+	level  string
+}
+
+// WithWriter sends every line to w as JSON instead of the configured console
+// and file outputs; no log directory or file is created. A nil w is ignored.
+func WithWriter(w io.Writer) Option {
+	return func(o *initOptions) {
+		if w != nil {
+			o.writer = w
+		}
+	}
+}
+
+// WithClock makes the timestamp field use now instead of zerolog.TimestampFunc,
+// e.g. for deterministic output in tests. It only has an effect when
+// WithTimestamp is enabled. A nil now is ignored.
+func WithClock(now func() time.Time) Option {
+	return func(o *initOptions) {
+		if now != nil {
+			o.clock = now
+		}
+	}
+}
+
+// WithLevel overrides LoggingConfig.Level (including any environment
+// override). The level is validated like the configured one.
+func WithLevel(level string) Option {
+	return func(o *initOptions) {
+		o.level = level
+	}
+}
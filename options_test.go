@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/logging/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitialize_WithWriter(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := validLoggingConfig()
+	cfg.FileLogging = true
+	cfg.ConsoleLogging = false
+	cfg.RelLogFileDir = "logs"
+
+	var buf threadSafeBuffer
+	service := &Service{WorkingDir: tmpDir, ConfigService: newTestConfigService(cfg)}
+	require.NoError(t, service.Initialize(WithWriter(&buf)))
+	defer func() { _ = service.Close() }()
+
+	service.InfoWith().Str("k", "v").Msg("captured")
+
+	logtest.AssertField(t, []byte(buf.String()), "message", "captured")
+	logtest.AssertField(t, []byte(buf.String()), "k", "v")
+	assert.Nil(t, service.fileWriter)
+	_, err := os.Stat(filepath.Join(tmpDir, cfg.RelLogFileDir))
+	assert.True(t, os.IsNotExist(err), "no log directory is created")
+}
+
+func TestInitialize_WithClock(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.WithTimestamp = true
+	fixed := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	var buf threadSafeBuffer
+	service := &Service{WorkingDir: t.TempDir(), ConfigService: newTestConfigService(cfg)}
+	require.NoError(t, service.Initialize(WithWriter(&buf), WithClock(func() time.Time { return fixed })))
+	defer func() { _ = service.Close() }()
+
+	service.InfoWith().Msg("one")
+	service.InfoWith().Msg("two")
+
+	lines, err := logtest.DecodeLines(strings.NewReader(buf.String()))
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Equal(t, "2024-03-01T12:30:00Z", line["time"])
+	}
+}
+
+func TestInitialize_WithLevel(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.Level = "debug"
+
+	var buf threadSafeBuffer
+	service := &Service{WorkingDir: t.TempDir(), ConfigService: newTestConfigService(cfg)}
+	require.NoError(t, service.Initialize(WithWriter(&buf), WithLevel("warn")))
+	defer func() { _ = service.Close() }()
+
+	service.InfoWith().Msg("dropped")
+	service.WarnWith().Msg("kept")
+
+	lines, err := logtest.DecodeLines(strings.NewReader(buf.String()))
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "kept", lines[0]["message"])
+	assert.Equal(t, "warn", service.LoggingConfig.Level)
+}
+
+func TestInitialize_InvalidLevelOption(t *testing.T) {
+	service := &Service{WorkingDir: t.TempDir(), ConfigService: newTestConfigService(validLoggingConfig())}
+	err := service.Initialize(WithLevel("loud"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validateConfig")
+	assert.False(t, service.isInitialized.Load())
+}
+
+func TestInitialize_NilOptionsIgnored(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.FileLogging = true
+	cfg.ConsoleLogging = false
+
+	service := &Service{WorkingDir: t.TempDir(), ConfigService: newTestConfigService(cfg)}
+	require.NoError(t, service.Initialize(nil, WithWriter(nil), WithClock(nil)))
+	defer func() { _ = service.Close() }()
+	assert.NotNil(t, service.fileWriter, "the configured outputs are used")
+	assert.Nil(t, service.clock.Load())
+}
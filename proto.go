@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// ProtoMessage is the method set every generated protobuf message implements
+// (protoiface.MessageV1), so that Proto accepts proto.Message values without
+// this package depending on the protobuf module.
+type ProtoMessage interface {
+	Reset()
+	String() string
+	ProtoMessage()
+}
+
+// SetProtoMarshaler sets the function Proto uses to turn a message into JSON.
+// The default, encoding/json, follows the json tags of generated messages;
+// install protojson for the canonical mapping (enum names, well-known types):
+//
+//	svc.SetProtoMarshaler(func(m logging.ProtoMessage) ([]byte, error) {
+//		return protojson.Marshal(protoadapt.MessageV2Of(m))
+//	})
+//
+// Passing nil restores the default. fn must be safe for concurrent use.
+func (s *Service) SetProtoMarshaler(fn func(msg ProtoMessage) ([]byte, error)) {
+	if s == nil {
+		return
+	}
+	if fn == nil {
+		s.protoMarshaler.Store(nil)
+		return
+	}
+	s.protoMarshaler.Store(&fn)
+}
+
+// marshalProto marshals msg with the configured marshaler and reports false if
+// that fails or does not produce valid JSON.
+func (s *Service) marshalProto(msg ProtoMessage) ([]byte, bool) {
+	var data []byte
+	var err error
+	var fn *func(msg ProtoMessage) ([]byte, error)
+	if s != nil {
+		fn = s.protoMarshaler.Load()
+	}
+	if fn != nil {
+		data, err = (*fn)(msg)
+	} else {
+		data, err = json.Marshal(msg)
+	}
+	if err != nil || !json.Valid(data) {
+		return nil, false
+	}
+	return data, true
+}
+
+func (e *logEvent) Proto(key string, msg ProtoMessage) LogEvent {
+	if e.event == nil || isNilProto(msg) {
+		return e.chain()
+	}
+	key, msg, ok := transformAttr(e, key, msg)
+	if !ok || isNilProto(msg) {
+		return e.chain()
+	}
+	if data, ok := e.service.marshalProto(msg); ok {
+		e.event.RawJSON(key, data)
+	} else {
+		// Fall back to the message's text format rather than losing it
+		e.event.Str(key, msg.String())
+	}
+	return e.chain()
+}
+
+// isNilProto reports whether msg is nil or a typed nil pointer, as passed for
+// an unset message field.
+func isNilProto(msg ProtoMessage) bool {
+	if msg == nil {
+		return true
+	}
+	v := reflect.ValueOf(msg)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
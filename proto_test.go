@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testQSO mimics a generated protobuf message: json tags, the MessageV1
+// methods and unexported internal state.
+type testQSO struct {
+	state    int
+	Call     string         `json:"call,omitempty"`
+	FreqKhz  int64          `json:"freq_khz,omitempty"`
+	Operator *testOperator  `json:"operator,omitempty"`
+	Tags     map[string]int `json:"tags,omitempty"`
+}
+
+type testOperator struct {
+	Name string `json:"name,omitempty"`
+}
+
+func (m *testQSO) Reset()         { *m = testQSO{} }
+func (m *testQSO) String() string { return fmt.Sprintf("call:%q", m.Call) }
+func (m *testQSO) ProtoMessage()  {}
+
+func TestLogEvent_Proto(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	msg := &testQSO{state: 1, Call: "M0ABC", FreqKhz: 14074, Operator: &testOperator{Name: "Ann"}}
+	service.InfoWith().Proto("qso", msg).Msg("logged")
+
+	var nilMsg *testQSO
+	service.InfoWith().Proto("qso", nilMsg).Proto("other", nil).Msg("nil")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+
+	qso, ok := entries[0]["qso"].(map[string]interface{})
+	require.True(t, ok, "qso is a nested object, got %T", entries[0]["qso"])
+	assert.Equal(t, "M0ABC", qso["call"])
+	assert.Equal(t, float64(14074), qso["freq_khz"])
+	assert.Equal(t, map[string]interface{}{"name": "Ann"}, qso["operator"])
+	assert.NotContains(t, qso, "state")
+
+	assert.NotContains(t, entries[1], "qso")
+	assert.NotContains(t, entries[1], "other")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestLogEvent_ProtoCustomMarshaler(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.SetProtoMarshaler(func(msg ProtoMessage) ([]byte, error) {
+		return json.Marshal(map[string]string{"text": msg.String()})
+	})
+	service.InfoWith().Proto("qso", &testQSO{Call: "G4XYZ"}).Msg("custom")
+
+	service.SetProtoMarshaler(func(ProtoMessage) ([]byte, error) { return nil, errors.New("boom") })
+	service.InfoWith().Proto("qso", &testQSO{Call: "G4XYZ"}).Msg("failed")
+
+	service.SetProtoMarshaler(func(ProtoMessage) ([]byte, error) { return []byte("{not json"), nil })
+	service.InfoWith().Proto("qso", &testQSO{Call: "G4XYZ"}).Msg("invalid")
+
+	service.SetProtoMarshaler(nil)
+	service.InfoWith().Proto("qso", &testQSO{Call: "G4XYZ"}).Msg("default")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 4)
+	assert.Equal(t, map[string]interface{}{"text": `call:"G4XYZ"`}, entries[0]["qso"])
+	assert.Equal(t, `call:"G4XYZ"`, entries[1]["qso"], "falls back to the text format")
+	assert.Equal(t, `call:"G4XYZ"`, entries[2]["qso"])
+	assert.Equal(t, map[string]interface{}{"call": "G4XYZ"}, entries[3]["qso"])
+}
@@ -0,0 +1,128 @@
+package logging
+
+import (
+	stderrs "errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Lumberjack's backup naming: <name>-<backupTimeFormat><ext>[.gz], in UTC.
+const (
+	backupTimeFormat     = "2006-01-02T15-04-05.000"
+	backupCompressSuffix = ".gz"
+)
+
+// maxPruneScan bounds the number of directory entries pruneBackups reads, so a
+// directory holding a huge number of files cannot stall Initialize.
+const maxPruneScan = 100000
+
+// pruneReadBatch is the number of directory entries read per ReadDir call.
+const pruneReadBatch = 1024
+
+// backupFile is a rotated backup of a log file.
+type backupFile struct {
+	name string
+	time time.Time
+}
+
+// pruneBackups removes the lumberjack backups of filename that exceed
+// maxBackups (newest kept) or are older than maxAgeDays; zero disables either
+// limit. Backups removed concurrently by another process are not an error. It
+// returns the number of files removed and the first error encountered.
+func pruneBackups(filename string, maxBackups, maxAgeDays int, now time.Time) (int, error) {
+	if maxBackups <= 0 && maxAgeDays <= 0 {
+		return 0, nil
+	}
+	dir := filepath.Dir(filename)
+	backups, err := listBackups(dir, filepath.Base(filename))
+	if err != nil {
+		return 0, err
+	}
+
+	// Newest first
+	sort.Slice(backups, func(i, j int) bool { return backups[i].time.After(backups[j].time) })
+	cutoff := now.Add(-time.Duration(maxAgeDays) * 24 * time.Hour)
+	removed := 0
+	var firstErr error
+	for i, b := range backups {
+		tooMany := maxBackups > 0 && i >= maxBackups
+		tooOld := maxAgeDays > 0 && b.time.Before(cutoff)
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, b.name)); err != nil {
+			if !stderrs.Is(err, os.ErrNotExist) && firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		removed++
+	}
+	return removed, firstErr
+}
+
+// listBackups returns the backups of the log file base found in dir, reading at
+// most maxPruneScan entries.
+func listBackups(dir, base string) ([]backupFile, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		if stderrs.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "-"
+	var backups []backupFile
+	for scanned := 0; scanned < maxPruneScan; {
+		entries, err := f.ReadDir(pruneReadBatch)
+		for _, e := range entries {
+			if !e.Type().IsRegular() {
+				continue
+			}
+			if t, ok := backupTime(e.Name(), prefix, ext); ok {
+				backups = append(backups, backupFile{name: e.Name(), time: t})
+			}
+		}
+		scanned += len(entries)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return backups, err
+		}
+	}
+	return backups, nil
+}
+
+// backupTime parses the rotation time from a backup file name, reporting false
+// for files that are not backups of the log file.
+func backupTime(name, prefix, ext string) (time.Time, bool) {
+	name = strings.TrimSuffix(name, backupCompressSuffix)
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+		return time.Time{}, false
+	}
+	ts := name[len(prefix) : len(name)-len(ext)]
+	t, err := time.Parse(backupTimeFormat, ts)
+	return t, err == nil
+}
+
+// pruneOnInit prunes the backups of filename per the configured rotation
+// limits when Config.PruneOnInit is set. Failures are reported on stderr and do
+// not fail Initialize.
+func (s *Service) pruneOnInit(filename string) {
+	if !s.Config.PruneOnInit {
+		return
+	}
+	_, err := pruneBackups(filename, s.LoggingConfig.LogFileMaxBackups, s.LoggingConfig.LogFileMaxAgeDays, time.Now())
+	if err != nil {
+		_, _ = fmt.Fprintf(s.stderrOut(), "logging: pruning old log backups failed (%v)\n", err)
+	}
+}
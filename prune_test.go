@@ -0,0 +1,123 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedBackups creates n backups of dir/app.log, one hour apart starting at
+// newest, and returns their names newest first.
+func seedBackups(t *testing.T, dir string, n int, newest time.Time) []string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	names := make([]string, n)
+	for i := range names {
+		ts := newest.Add(-time.Duration(i) * time.Hour).UTC().Format(backupTimeFormat)
+		names[i] = "app-" + ts + ".log"
+		if i%2 == 1 {
+			names[i] += backupCompressSuffix
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(dir, names[i]), []byte("old\n"), 0o644))
+	}
+	return names
+}
+
+func dirNames(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestPruneOnInit_MaxBackups(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.ConsoleLogging = false
+	cfg.FileLogging = true
+	cfg.RelLogFileDir = "logs"
+	cfg.LogFileMaxBackups = 5
+	workDir := t.TempDir()
+	dir := filepath.Join(workDir, "logs")
+
+	backups := seedBackups(t, dir, 300, time.Now())
+	unrelated := []string{"app.log", "other-2020-01-01T00-00-00.000.log", "app-notes.log"}
+	for _, name := range unrelated {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("keep\n"), 0o644))
+	}
+
+	service := &Service{
+		WorkingDir:    workDir,
+		ConfigService: newTestConfigService(cfg),
+		Config:        Config{PruneOnInit: true, LogFileName: "app.log"},
+	}
+	require.NoError(t, service.Initialize())
+	t.Cleanup(func() { _ = service.Close() })
+
+	want := append(append([]string{}, backups[:5]...), unrelated...)
+	sort.Strings(want)
+	assert.Equal(t, want, dirNames(t, dir))
+}
+
+func TestPruneOnInit_MaxAge(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.ConsoleLogging = false
+	cfg.FileLogging = true
+	cfg.RelLogFileDir = "logs"
+	cfg.LogFileMaxBackups = 100
+	cfg.LogFileMaxAgeDays = 1
+	workDir := t.TempDir()
+	dir := filepath.Join(workDir, "logs")
+
+	// Hourly backups over two days: only the last 24 hours survive
+	backups := seedBackups(t, dir, 48, time.Now().Add(-30*time.Minute))
+
+	service := &Service{
+		WorkingDir:    workDir,
+		ConfigService: newTestConfigService(cfg),
+		Config:        Config{PruneOnInit: true, LogFileName: "app.log"},
+	}
+	require.NoError(t, service.Initialize())
+	t.Cleanup(func() { _ = service.Close() })
+
+	want := append([]string{}, backups[:24]...)
+	sort.Strings(want)
+	assert.Equal(t, want, dirNames(t, dir))
+}
+
+func TestPruneOnInit_OffByDefault(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.ConsoleLogging = false
+	cfg.FileLogging = true
+	cfg.RelLogFileDir = "logs"
+	cfg.LogFileMaxBackups = 1
+	workDir := t.TempDir()
+	dir := filepath.Join(workDir, "logs")
+	backups := seedBackups(t, dir, 10, time.Now())
+
+	service := &Service{
+		WorkingDir:    workDir,
+		ConfigService: newTestConfigService(cfg),
+		Config:        Config{LogFileName: "app.log"},
+	}
+	require.NoError(t, service.Initialize())
+	t.Cleanup(func() { _ = service.Close() })
+
+	sort.Strings(backups)
+	assert.Equal(t, backups, dirNames(t, dir))
+}
+
+func TestPruneBackups_MissingDir(t *testing.T) {
+	removed, err := pruneBackups(filepath.Join(t.TempDir(), "none", "app.log"), 1, 1, time.Now())
+	assert.NoError(t, err)
+	assert.Zero(t, removed)
+}
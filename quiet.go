@@ -0,0 +1,38 @@
+package logging
+
+import "github.com/rs/zerolog"
+
+// SetQuiet raises the effective level to error while quiet is true, e.g. for a
+// CLI --quiet flag, and restores the configured level when it is set back to
+// false. Audit events are still written. It applies to events created after
+// the call, including those of context loggers created earlier with With(),
+// and is safe for concurrent use with logging.
+func (s *Service) SetQuiet(quiet bool) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.quiet.Store(quiet)
+	logger := s.logger.Load()
+	if logger == nil {
+		return
+	}
+	// Validated at Initialize
+	level, err := parseLevel(s.LoggingConfig.Level)
+	if err != nil {
+		return
+	}
+	quieted := logger.Level(s.quietLevel(level))
+	s.logger.Store(&quieted)
+}
+
+// quietLevel returns level, raised to error while the service is quiet.
+func (s *Service) quietLevel(level zerolog.Level) zerolog.Level {
+	if s.quiet.Load() && level < zerolog.ErrorLevel {
+		return zerolog.ErrorLevel
+	}
+	return level
+}
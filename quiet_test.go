@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SetQuiet(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	child := service.With().Str("component", "cli").Logger()
+
+	service.SetQuiet(true)
+	service.InfoWith().Msg("hidden")
+	service.DebugWith().Msg("hidden")
+	child.InfoWith().Msg("hidden")
+	service.ErrorWith().Msg("shown while quiet")
+	service.AuditWith().Msg("audit while quiet")
+
+	service.SetQuiet(false)
+	service.InfoWith().Msg("resumed")
+	child.DebugWith().Msg("child resumed")
+
+	var messages []string
+	for _, entry := range readLogEntries(t, dir, logFileName(service)) {
+		messages = append(messages, entry["message"].(string))
+	}
+	assert.Equal(t, []string{"shown while quiet", "audit while quiet", "resumed", "child resumed"}, messages)
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestService_SetQuiet_Concurrent(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				service.InfoWith().Int("j", j).Msg("tick")
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		service.SetQuiet(i%2 == 0)
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(0), service.ActiveOperations())
+	var nilService *Service
+	nilService.SetQuiet(true)
+}
@@ -0,0 +1,32 @@
+package logging
+
+import "time"
+
+// RetryAttempt logs one attempt of a retry loop with operation, attempt and
+// backoff_ms (the backoff passed in, typically the delay before the next
+// attempt). A failed attempt (err != nil) is logged at Warn level with full
+// error enrichment; a successful one at Info.
+// Example:
+//
+//	for attempt := 1; ; attempt++ {
+//		err := dial()
+//		svc.RetryAttempt("radio.Dial", attempt, backoff, err)
+//		if err == nil { break }
+//		time.Sleep(backoff)
+//	}
+func (s *Service) RetryAttempt(op string, attempt int, backoff time.Duration, err error) {
+	if err == nil {
+		s.InfoWith().
+			Str("operation", op).
+			Int("attempt", attempt).
+			Dur("backoff_ms", backoff).
+			Msg("attempt succeeded")
+		return
+	}
+	s.WarnWith().
+		Str("operation", op).
+		Int("attempt", attempt).
+		Dur("backoff_ms", backoff).
+		Err(err).
+		Msg("attempt failed, retrying")
+}
@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	smerrors "github.com/Station-Manager/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAttempt(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	errRefused := smerrors.New("radio.Connect").Msg("connection refused")
+	backoff := 100 * time.Millisecond
+	for attempt := 1; attempt <= 3; attempt++ {
+		service.RetryAttempt("radio.Dial", attempt, backoff, errRefused)
+		backoff *= 2
+	}
+	service.RetryAttempt("radio.Dial", 4, backoff, nil)
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 4)
+	for i, entry := range entries[:3] {
+		assert.Equal(t, "warn", entry["level"])
+		assert.Equal(t, "radio.Dial", entry["operation"])
+		assert.Equal(t, float64(i+1), entry["attempt"])
+		assert.Equal(t, float64(int(100)<<i), entry["backoff_ms"])
+		assert.Equal(t, "connection refused", entry["error_root"])
+		assert.Equal(t, "radio.Connect", entry["error_root_op"])
+	}
+
+	success := entries[3]
+	assert.Equal(t, "info", success["level"])
+	assert.Equal(t, float64(4), success["attempt"])
+	assert.Equal(t, "radio.Dial", success["operation"])
+	assert.NotContains(t, success, "error")
+	assert.Equal(t, float64(800), success["backoff_ms"])
+}
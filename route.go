@@ -0,0 +1,225 @@
+package logging
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Station-Manager/errors"
+	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// maxRoutedFiles bounds the per-value files a field route keeps open; the least
+// recently written file is closed when another value arrives.
+const maxRoutedFiles = 64
+
+// maxRoutedNameLen bounds the length of a sanitized routed file name (without .log).
+const maxRoutedNameLen = 128
+
+// routedFile is an open per-value file in a fieldRouter's LRU list.
+type routedFile struct {
+	name string
+	lj   *lumberjack.Logger
+	w    io.Writer
+}
+
+// fieldRouter copies lines carrying a string field to dir/<value>.log.
+type fieldRouter struct {
+	service *Service
+	key     string
+	needle  []byte // `"key":` prefilter, avoids decoding lines without the field
+	dir     string
+	// reserved holds the paths of the service's own log files, which a routed
+	// value must not open a second writer on
+	reserved []string
+
+	mu     sync.Mutex
+	files  map[string]*list.Element
+	lru    *list.List // Front is the most recently written file
+	closed bool
+}
+
+func newFieldRouter(s *Service, key, dir string, reserved []string) *fieldRouter {
+	needle, _ := json.Marshal(key)
+	return &fieldRouter{
+		service:  s,
+		key:      key,
+		needle:   append(needle, ':'),
+		dir:      dir,
+		reserved: reserved,
+		files:    make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// isReserved reports whether the routed file for name would be one of the
+// service's own log files. The comparison ignores case for case-insensitive
+// file systems.
+func (r *fieldRouter) isReserved(name string) bool {
+	path := filepath.Join(r.service.WorkingDir, r.dir, name+".log")
+	for _, own := range r.reserved {
+		if strings.EqualFold(path, own) {
+			return true
+		}
+	}
+	return false
+}
+
+// route writes p to the file for the value of the routed field, if p carries it.
+func (r *fieldRouter) route(p []byte) {
+	if !bytes.Contains(p, r.needle) {
+		return
+	}
+	fields, ok := splitJSONObject(p)
+	if !ok {
+		return
+	}
+	var value string
+	found := false
+	for _, f := range fields {
+		if f.key == r.key {
+			found = json.Unmarshal(f.raw, &value) == nil
+			break
+		}
+	}
+	if !found {
+		return
+	}
+	name := sanitizeRouteValue(value)
+	if name == emptyString || r.isReserved(name) {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	_, _ = r.fileLocked(name).w.Write(p)
+}
+
+// fileLocked returns the open file for name, opening it (and closing the least
+// recently used file if the bound is reached) as needed. r.mu must be held.
+func (r *fieldRouter) fileLocked(name string) *routedFile {
+	if el, ok := r.files[name]; ok {
+		r.lru.MoveToFront(el)
+		return el.Value.(*routedFile)
+	}
+	if r.lru.Len() >= maxRoutedFiles {
+		oldest := r.lru.Back()
+		rf := oldest.Value.(*routedFile)
+		_ = rf.lj.Close()
+		r.lru.Remove(oldest)
+		delete(r.files, rf.name)
+	}
+	lj := r.service.newRollingFileLoggerIn(r.dir, name+".log")
+	rf := &routedFile{name: name, lj: lj, w: newWriteErrorWriter(lj, r.service)}
+	r.files[name] = r.lru.PushFront(rf)
+	return rf
+}
+
+// close closes every open file; later lines are no longer routed.
+func (r *fieldRouter) close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	var firstErr error
+	for el := r.lru.Front(); el != nil; el = el.Next() {
+		if err := el.Value.(*routedFile).lj.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	r.files = nil
+	r.lru.Init()
+	return firstErr
+}
+
+// sanitizeRouteValue turns a field value into a safe file name: characters
+// other than letters, digits, '-', '_' and '.' become '_', and names made only
+// of dots (or empty) are rejected.
+func sanitizeRouteValue(value string) string {
+	if len(value) > maxRoutedNameLen {
+		value = value[:maxRoutedNameLen]
+	}
+	name := []byte(value)
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			name[i] = '_'
+		}
+	}
+	if strings.Trim(string(name), ".") == emptyString {
+		return emptyString
+	}
+	return string(name)
+}
+
+// routeWriter passes each line to the service's field router, if one is set,
+// before passing it on.
+type routeWriter struct {
+	w       zerolog.LevelWriter
+	service *Service
+}
+
+// Write implements io.Writer.
+func (rw *routeWriter) Write(p []byte) (int, error) {
+	return rw.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+func (rw *routeWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if r := rw.service.fieldRoute.Load(); r != nil {
+		r.route(p)
+	}
+	return rw.w.WriteLevel(level, p)
+}
+
+// RouteByField additionally writes every line that carries a string field key
+// to dir/<value>.log, for example to keep a file per tenant. dir is relative
+// to WorkingDir and follows the same rules as RelLogFileDir; the files use the
+// configured rotation limits. Values are sanitized into file names, and at most
+// 64 files are kept open at once (least recently used first to close). Values
+// that would name the service's own log file or errors.log are not routed, so
+// that no file gets a second writer rotating it. The files are closed on Close.
+// Calling it again replaces the previous route.
+func (s *Service) RouteByField(key string, dir string) error {
+	const op errors.Op = "logging.Service.RouteByField"
+	if s == nil {
+		return errors.New(op).Msg(errMsgNilService)
+	}
+	if key == emptyString {
+		return errors.New(op).Msg("key cannot be empty")
+	}
+	if dir == emptyString {
+		return errors.New(op).Msg("dir cannot be empty")
+	}
+	cleanDir := filepath.Clean(dir)
+	if strings.Contains(cleanDir, "..") {
+		return errors.New(op).Msg("dir cannot contain '..' (directory traversal)")
+	}
+	if filepath.IsAbs(cleanDir) {
+		return errors.New(op).Msg("dir must be a relative path")
+	}
+
+	var reserved []string
+	s.mu.RLock()
+	for _, lj := range []*lumberjack.Logger{s.fileWriter, s.errFileWriter} {
+		if lj != nil {
+			reserved = append(reserved, filepath.Clean(lj.Filename))
+		}
+	}
+	s.mu.RUnlock()
+
+	if old := s.fieldRoute.Swap(newFieldRouter(s, key, cleanDir, reserved)); old != nil {
+		if err := old.close(); err != nil {
+			return errors.New(op).Errorf("old.close: %w", err)
+		}
+	}
+	return nil
+}
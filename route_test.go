@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteByField(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	require.NoError(t, service.RouteByField("tenant", "tenants"))
+
+	service.InfoWith().Str("tenant", "acme").Msg("acme one")
+	service.InfoWith().Str("tenant", "globex").Msg("globex one")
+	service.InfoWith().Str("tenant", "acme").Msg("acme two")
+	service.InfoWith().Msg("no tenant")
+
+	routedDir := filepath.Join(service.WorkingDir, "tenants")
+	acme := readLogEntries(t, routedDir, "acme.log")
+	require.Len(t, acme, 2)
+	assert.Equal(t, "acme one", acme[0]["message"])
+	assert.Equal(t, "acme two", acme[1]["message"])
+
+	globex := readLogEntries(t, routedDir, "globex.log")
+	require.Len(t, globex, 1)
+	assert.Equal(t, "globex one", globex[0]["message"])
+	assert.Equal(t, "globex", globex[0]["tenant"])
+
+	// Every line still reaches the main log file
+	assert.Len(t, readLogEntries(t, dir, logFileName(service)), 4)
+
+	files, err := os.ReadDir(routedDir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	require.NoError(t, service.Close())
+	assert.Nil(t, service.fieldRoute.Load())
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestRouteByField_SanitizesValues(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+	require.NoError(t, service.RouteByField("tenant", "tenants"))
+
+	service.InfoWith().Str("tenant", "../../etc/passwd").Msg("traversal")
+	service.InfoWith().Str("tenant", "..").Msg("dots only")
+
+	files, err := os.ReadDir(filepath.Join(service.WorkingDir, "tenants"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, ".._.._etc_passwd.log", files[0].Name())
+}
+
+func TestRouteByField_EvictsLeastRecentlyUsed(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+	require.NoError(t, service.RouteByField("tenant", "tenants"))
+
+	for i := 0; i <= maxRoutedFiles; i++ {
+		service.InfoWith().Int("n", i).Str("tenant", fmt.Sprintf("t%02d", i)).Msg("line")
+	}
+
+	router := service.fieldRoute.Load()
+	require.NotNil(t, router)
+	router.mu.Lock()
+	open := router.lru.Len()
+	_, firstOpen := router.files["t00"]
+	router.mu.Unlock()
+	assert.Equal(t, maxRoutedFiles, open)
+	assert.False(t, firstOpen)
+}
+
+func TestRouteByField_Validation(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+
+	assert.Error(t, service.RouteByField("", "tenants"))
+	assert.Error(t, service.RouteByField("tenant", ""))
+	assert.Error(t, service.RouteByField("tenant", "../tenants"))
+	assert.Error(t, service.RouteByField("tenant", "/tmp/tenants"))
+
+	var nilService *Service
+	assert.Error(t, nilService.RouteByField("tenant", "tenants"))
+}
+
+func TestSanitizeRouteValue(t *testing.T) {
+	assert.Equal(t, "acme", sanitizeRouteValue("acme"))
+	assert.Equal(t, "a_b_c", sanitizeRouteValue("a/b c"))
+	assert.Equal(t, "", sanitizeRouteValue(""))
+	assert.Equal(t, "", sanitizeRouteValue("..."))
+	assert.Len(t, sanitizeRouteValue(string(make([]byte, 500))), maxRoutedNameLen)
+}
+
+func TestRouteByField_SkipsServiceLogFiles(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{ErrorFileEnabled: true})
+	main := logFileName(service)
+	require.NoError(t, service.RouteByField("tenant", "."))
+
+	service.InfoWith().Str("tenant", strings.TrimSuffix(main, ".log")).Msg("main name")
+	service.ErrorWith().Str("tenant", "ERRORS").Msg("errors name")
+	service.InfoWith().Str("tenant", "acme").Msg("acme")
+
+	// Each line reaches the service's own files once, through their own writers
+	assert.Len(t, readLogEntries(t, dir, main), 3)
+	assert.Len(t, readLogEntries(t, dir, errorLogFileName), 1)
+	assert.Len(t, readLogEntries(t, dir, "acme.log"), 1)
+	require.NoError(t, service.Close())
+}
@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"runtime"
+
+	"github.com/rs/zerolog"
+)
+
+// LogRuntimeStats writes a Debug line with a snapshot of the Go runtime:
+// goroutines, heap_alloc (bytes), heap_objects, gc_cycles and next_gc (the heap
+// size target of the next GC, in bytes). runtime.ReadMemStats briefly stops the
+// world, so call it on demand rather than per request. Like any Debug line it is
+// dropped, without reading the stats, when the level is above debug.
+func (s *Service) LogRuntimeStats() {
+	e := logEventBuilder(s, zerolog.DebugLevel)
+	if e == noopEvent {
+		return
+	}
+
+	// The MemStats struct is reused; it is large enough to be worth not allocating
+	s.memStatsMu.Lock()
+	runtime.ReadMemStats(&s.memStats)
+	heapAlloc := s.memStats.HeapAlloc
+	heapObjects := s.memStats.HeapObjects
+	gcCycles := s.memStats.NumGC
+	nextGC := s.memStats.NextGC
+	s.memStatsMu.Unlock()
+
+	e.Int("goroutines", runtime.NumGoroutine()).
+		Uint64("heap_alloc", heapAlloc).
+		Uint64("heap_objects", heapObjects).
+		Uint64("gc_cycles", uint64(gcCycles)).
+		Uint64("next_gc", nextGC).
+		Msg("runtime stats")
+}
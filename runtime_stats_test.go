@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogRuntimeStats(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.LogRuntimeStats()
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "debug", entry["level"])
+	assert.Equal(t, "runtime stats", entry["message"])
+	assert.GreaterOrEqual(t, entry["goroutines"], float64(1))
+	assert.Greater(t, entry["heap_alloc"], float64(0))
+	assert.Greater(t, entry["heap_objects"], float64(0))
+	assert.Greater(t, entry["next_gc"], float64(0))
+	assert.Contains(t, entry, "gc_cycles")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestLogRuntimeStats_DisabledBelowDebug(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.Level = "info"
+	service, dir := newFileTestService(t, cfg, Config{})
+
+	service.LogRuntimeStats()
+	service.InfoWith().Msg("marker")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "marker", entries[0]["message"])
+}
+
+func TestLogRuntimeStats_NilService(t *testing.T) {
+	var service *Service
+	assert.NotPanics(t, service.LogRuntimeStats)
+}
@@ -0,0 +1,93 @@
+package logging
+
+import "sync"
+
+// scopeStack is the stack of loggers pushed for one token.
+type scopeStack struct {
+	mu      sync.Mutex
+	loggers []Logger
+}
+
+// Push adds a scope for token: fields is applied to the token's current logger
+// (see Current) and the resulting logger becomes the new current logger. Each
+// Push must be paired with a Pop for the same token, typically via defer.
+// Tokens are chosen by the caller (for example a job or request id) since Go has
+// no goroutine-local storage; a token should not be shared by goroutines that
+// push concurrently.
+// Example:
+//
+//	svc.Push(jobID, func(c LogContext) LogContext { return c.Str("job_id", jobID) })
+//	defer svc.Pop(jobID)
+//	svc.Current(jobID).InfoWith().Msg("started")
+func (s *Service) Push(token string, fields func(LogContext) LogContext) {
+	if s == nil {
+		return
+	}
+	var st *scopeStack
+	for {
+		v, _ := s.scopes.LoadOrStore(token, &scopeStack{})
+		st = v.(*scopeStack)
+		st.mu.Lock()
+		// A concurrent Pop may have removed the emptied stack from the map
+		if cur, ok := s.scopes.Load(token); ok && cur == st {
+			break
+		}
+		st.mu.Unlock()
+	}
+	defer st.mu.Unlock()
+
+	var base Logger = s
+	if n := len(st.loggers); n > 0 {
+		base = st.loggers[n-1]
+	}
+	next := base
+	if fields != nil {
+		next = fields(base.With()).Logger()
+	}
+	st.loggers = append(st.loggers, next)
+}
+
+// Pop removes the most recent scope pushed for token. Popping a token with no
+// scopes is a no-op.
+func (s *Service) Pop(token string) {
+	if s == nil {
+		return
+	}
+	v, ok := s.scopes.Load(token)
+	if !ok {
+		return
+	}
+	st := v.(*scopeStack)
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if n := len(st.loggers); n > 0 {
+		st.loggers[n-1] = nil
+		st.loggers = st.loggers[:n-1]
+	}
+	if len(st.loggers) == 0 {
+		s.scopes.CompareAndDelete(token, st)
+	}
+}
+
+// Current returns the logger carrying the fields of every scope pushed for
+// token, or the Service itself if none are active.
+func (s *Service) Current(token string) Logger {
+	if s == nil {
+		return &noopLogger{}
+	}
+	v, ok := s.scopes.Load(token)
+	if !ok {
+		return s
+	}
+	st := v.(*scopeStack)
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if n := len(st.loggers); n > 0 {
+		return st.loggers[n-1]
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_PushPop(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	const token = "job-1"
+
+	service.Push(token, func(c LogContext) LogContext { return c.Str("job_id", "1") })
+	service.Current(token).InfoWith().Msg("outer")
+
+	service.Push(token, func(c LogContext) LogContext { return c.Str("step", "fetch") })
+	service.Current(token).InfoWith().Msg("inner")
+	service.Current("job-2").InfoWith().Msg("other token")
+	service.Pop(token)
+
+	service.Current(token).InfoWith().Msg("outer again")
+	service.Pop(token)
+	service.Pop(token) // extra Pop is a no-op
+
+	service.Current(token).InfoWith().Msg("no scope")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 5)
+
+	assert.Equal(t, "1", entries[0]["job_id"])
+	assert.NotContains(t, entries[0], "step")
+
+	assert.Equal(t, "1", entries[1]["job_id"])
+	assert.Equal(t, "fetch", entries[1]["step"])
+
+	assert.NotContains(t, entries[2], "job_id")
+
+	assert.Equal(t, "1", entries[3]["job_id"])
+	assert.NotContains(t, entries[3], "step")
+
+	assert.NotContains(t, entries[4], "job_id")
+
+	_, ok := service.scopes.Load(token)
+	assert.False(t, ok, "empty stacks are removed")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestService_PushNilFields(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+	service.Push("t", nil)
+	assert.Same(t, service, service.Current("t"))
+	service.Pop("t")
+
+	var nilService *Service
+	nilService.Push("t", nil)
+	nilService.Pop("t")
+	assert.IsType(t, &noopLogger{}, nilService.Current("t"))
+}
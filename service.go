@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"context"
 	"github.com/Station-Manager/config"
 	"github.com/Station-Manager/errors"
 	"github.com/Station-Manager/types"
@@ -11,6 +12,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sync"
 	"time"
 )
@@ -27,25 +29,77 @@ import (
 // A Service must be initialized via Initialize() before use and closed with Close().
 // It is safe for concurrent use by multiple goroutines.
 type Service struct {
-	WorkingDir        string          `di.inject:"workingdir"`
-	ConfigService     *config.Service `di.inject:"configservice"`
-	LoggingConfig     *types.LoggingConfig
-	fileWriter        *lumberjack.Logger
-	logger            atomic.Pointer[zerolog.Logger]
-	isInitialized     atomic.Bool
-	initOnce          sync.Once
-	initErr           error
-	mu                sync.RWMutex
-	activeOps         atomic.Int32 // Track active logging operations
-	wg                sync.WaitGroup
-	activeOpLocations map[string]int // Debug: Track where active operations were created
+	WorkingDir         string          `di.inject:"workingdir"`
+	ConfigService      *config.Service `di.inject:"configservice"`
+	LoggingConfig      *types.LoggingConfig
+	Config             Config // Package-local settings; set before Initialize
+	fileWriter         *lumberjack.Logger
+	bufWriter          *bufferedWriter    // Wraps fileWriter when Config.FlushIntervalMS > 0
+	bufferedDrops      atomic.Int64       // Bytes the bufWriter lost to write errors
+	errFileWriter      *lumberjack.Logger // errors.log when Config.ErrorFileEnabled
+	timeoutWriters     []*timeoutWriter   // One per built logger when Config.WriteTimeoutMS > 0
+	dedupeWriters      []*dedupeWriter    // One per built logger when Config.DedupeWindowMS > 0
+	writeDrops         atomic.Int64       // Lines dropped by timeoutWriters
+	fifoWriter         io.WriteCloser     // Config.FifoPath writer, if set
+	fifoDrops          atomic.Int64       // Lines the fifoWriter could not deliver
+	logger             atomic.Pointer[zerolog.Logger]
+	isInitialized      atomic.Bool
+	initOnce           sync.Once
+	initErr            error
+	mu                 sync.RWMutex
+	activeOps          atomic.Int32 // Track active logging operations
+	peakOps            atomic.Int32 // High-water mark of activeOps
+	unbalancedReleases atomic.Int64 // Releases refused by releaseOp
+	wg                 sync.WaitGroup
+	activeOpLocations  map[string]int // Debug: Track where active operations were created
+	enrichment         errorEnrichmentMode
+	opsAllowPrefix     []string // Copy of Config.ErrorOpsAllowPrefix taken at Initialize
+	onWriteError       atomic.Pointer[func(error)]
+	lastWriteErr       atomic.Error
+	onShutdownTimeout  atomic.Pointer[func(active int32)]
+	fatalHook          atomic.Pointer[func()]
+	quiet              atomic.Bool // See SetQuiet
+	onFirstError       atomic.Pointer[func(msg string, err error)]
+	firstErrorFired    atomic.Bool                      // Set once the OnFirstError callback ran
+	breakers           sync.Map                         // key -> *LogBreaker, see Breaker
+	clock              atomic.Pointer[func() time.Time] // Timestamp source, see SetClock; nil means time.Now
+	exit               func(code int)                   // Process exit for fatal events; nil means os.Exit (overridden in tests)
+	uninitWarned       atomic.Bool                      // Set once the WarnOnUninitialized notice was written
+	fallbackOnce       sync.Once                        // Guards the one-time stderr fallback notice
+	stderr             io.Writer                        // Console and fallback destination; nil means os.Stderr (overridden in tests)
+	deprecations       keySet                           // Features already reported by Deprecated
+	onceKeys           keySet                           // Keys already logged by Once
+	stubFeatures       keySet                           // Features already reported by NotImplemented
+	reservedKeysWarned keySet                           // Reserved keys already reported by guardFieldKey
+	scopes             sync.Map                         // token -> *scopeStack, see Push
+	msgSampler         zerolog.Sampler                  // Applied at Msg time in first-per-message sampling mode
+	seenMessages       keySet                           // Error messages already written in first-per-message mode
+	seenCount          atomic.Int32
+	filter             atomic.Pointer[func(level zerolog.Level, msg string) bool]
+	attrTransformer    atomic.Pointer[func(key string, val interface{}) (string, interface{})]
+	protoMarshaler     atomic.Pointer[func(msg ProtoMessage) ([]byte, error)]
+	dumpers            sync.Map                       // reflect.Type -> func(interface{}) string, see RegisterDumper
+	dumperCount        atomic.Int32                   // Registered dumpers, so that lookups are skipped while there are none
+	dynamicFields      atomic.Pointer[[]dynamicField] // Copy-on-write, see AddDynamicField
+	startupBuf         atomic.Pointer[startupBuffer]  // Active between StartBuffering and FlushBufferTo
+	fieldRoute         atomic.Pointer[fieldRouter]    // Set by RouteByField, closed on Close
+	dynamicMu          sync.Mutex                     // Serializes AddDynamicField
+	subscribers        atomic.Pointer[[]*subscriber]  // Copy-on-write, see Subscribe
+	subsMu             sync.Mutex                     // Serializes changes to subscribers
+	accumulators       map[*Accumulator]struct{}      // Running accumulators, stopped on Close
+	accMu              sync.Mutex                     // Guards accumulators
+	breadcrumbs        breadcrumbRing                 // See Breadcrumb
+	memStatsMu         sync.Mutex                     // Guards memStats
+	memStats           runtime.MemStats               // Reused by LogRuntimeStats
 }
 
 // Initialize prepares the Service for use: it validates configuration, ensures
 // the log directory exists, sets up file/console writers, sets the log level,
 // and builds the zerolog logger with any requested timestamp or caller info.
+// Options (WithWriter, WithClock, WithLevel) adjust this call only; without
+// them the configuration is used as is.
 // Initialize is safe to call multiple times; subsequent calls are no-ops.
-func (s *Service) Initialize() error {
+func (s *Service) Initialize(opts ...Option) error {
 	const op errors.Op = "logging.Service.Initialize"
 	if s == nil {
 		return errors.New(op).Msg(errMsgNilService)
@@ -61,73 +115,305 @@ func (s *Service) Initialize() error {
 			s.initErr = errors.New(op).Errorf("s.AppConfig.LoggingConfig: %w", cfgErr)
 			return
 		}
-
-		if cfgErr = validateConfig(&loggingCfg); cfgErr != nil {
-			s.initErr = errors.New(op).Errorf("validateConfig: %w", cfgErr)
+		s.LoggingConfig = &loggingCfg
+		if envErr := s.ApplyEnvOverrides(); envErr != nil {
+			s.initErr = errors.New(op).Errorf("s.ApplyEnvOverrides: %w", envErr)
 			return
 		}
-		s.LoggingConfig = &loggingCfg
-
-		if s.WorkingDir == emptyString {
-			exeDir, pathErr := utils.AbsDirPathForExecutable()
-			if pathErr != nil {
-				s.initErr = errors.New(op).Errorf("utils.AbsDirPathForExecutable: %w", pathErr)
-				return
+		var o initOptions
+		for _, opt := range opts {
+			if opt != nil {
+				opt(&o)
 			}
-			s.WorkingDir = exeDir
 		}
+		s.initErr = s.initialize(loggingCfg, o)
+	})
 
-		loggingDir := filepath.Join(s.WorkingDir, s.LoggingConfig.RelLogFileDir)
-		exists, existsErr := utils.PathExists(loggingDir)
-		if existsErr != nil {
-			s.initErr = errors.New(op).Errorf("utils.PathExists: %w", existsErr)
-			return
-		}
+	return s.initErr
+}
 
-		if !exists {
-			if mdErr := os.MkdirAll(loggingDir, 0750); mdErr != nil {
-				s.initErr = errors.New(op).Errorf("os.MkdirAll: %w", mdErr)
-				return
-			}
+// initialize validates loggingCfg and builds the writers and logger. It must
+// only be called once per Service, from within initOnce.
+func (s *Service) initialize(loggingCfg types.LoggingConfig, o initOptions) error {
+	const op errors.Op = "logging.Service.initialize"
+	if o.level != emptyString {
+		loggingCfg.Level = o.level
+	}
+	if o.clock != nil {
+		s.clock.Store(&o.clock)
+	}
+	if cfgErr := validateConfig(&loggingCfg); cfgErr != nil {
+		return errors.New(op).Errorf("validateConfig: %w", cfgErr)
+	}
+	s.LoggingConfig = &loggingCfg
+
+	if cfgErr := validateLocalConfig(&s.Config); cfgErr != nil {
+		return errors.New(op).Errorf("validateLocalConfig: %w", cfgErr)
+	}
+	s.enrichment, _ = parseErrorEnrichment(s.Config.ErrorEnrichment)
+	s.opsAllowPrefix = s.Config.clone().ErrorOpsAllowPrefix
+
+	if s.Config.SampleBurst > 0 && s.Config.SampleMode == SampleModeFirstPerMessage {
+		// Sampled when the message is known, see trackedLogEvent.sampledOut
+		s.msgSampler = s.newSampler()
+	}
+
+	if s.WorkingDir == emptyString {
+		exeDir, pathErr := utils.AbsDirPathForExecutable()
+		if pathErr != nil {
+			return errors.New(op).Errorf("utils.AbsDirPathForExecutable: %w", pathErr)
 		}
+		s.WorkingDir = exeDir
+	}
 
-		exeName, exeErr := utils.ExecName(true)
-		if exeErr != nil {
-			s.initErr = errors.New(op).Errorf("utils.ExecName: %w", exeErr)
-			return
+	var writers []io.Writer
+	if o.writer != nil {
+		writers = []io.Writer{o.writer}
+	} else {
+		var writersErr error
+		if writers, writersErr = s.initializeOutputs(); writersErr != nil {
+			return errors.New(op).Errorf("s.initializeOutputs: %w", writersErr)
 		}
+	}
+
+	logger, buildErr := s.buildLogger(zerolog.MultiLevelWriter(writers...))
+	if buildErr != nil {
+		return errors.New(op).Err(buildErr).Msg("failed to build logger")
+	}
 
-		mw := io.MultiWriter(s.initializeWriters(exeName)...)
-		logger := zerolog.New(mw).With().Logger()
+	// Store logger atomically
+	s.logger.Store(&logger)
 
-		level, levelErr := parseLevel(s.LoggingConfig.Level)
-		if levelErr != nil {
-			s.initErr = errors.New(op).Errorf("parseLevel: %w", levelErr)
-			return
+	s.isInitialized.Store(true)
+
+	return nil
+}
+
+// initializeOutputs ensures the log directory exists and creates the configured
+// console and file writers.
+func (s *Service) initializeOutputs() ([]io.Writer, error) {
+	const op errors.Op = "logging.Service.initializeOutputs"
+	loggingDir := filepath.Join(s.WorkingDir, s.LoggingConfig.RelLogFileDir)
+	exists, existsErr := utils.PathExists(loggingDir)
+	if existsErr != nil {
+		return nil, errors.New(op).Errorf("utils.PathExists: %w", existsErr)
+	}
+
+	if exists {
+		info, statErr := os.Stat(loggingDir)
+		if statErr != nil {
+			return nil, errors.New(op).Errorf("os.Stat: %w", statErr)
+		}
+		if !info.IsDir() {
+			return nil, errors.New(op).Msgf("log directory '%s' (RelLogFileDir) is occupied by a file", loggingDir)
 		}
-		logger = logger.Level(level)
+	} else {
+		if mdErr := os.MkdirAll(loggingDir, 0750); mdErr != nil {
+			return nil, errors.New(op).Errorf("os.MkdirAll: %w", mdErr)
+		}
+	}
 
-		if s.LoggingConfig.WithTimestamp {
-			logger = logger.With().Timestamp().Logger()
+	exeName, exeErr := utils.ExecName(true)
+	if exeErr != nil {
+		return nil, errors.New(op).Errorf("utils.ExecName: %w", exeErr)
+	}
+
+	writers, writersErr := s.initializeWriters(exeName)
+	if writersErr != nil {
+		return nil, errors.New(op).Errorf("s.initializeWriters: %w", writersErr)
+	}
+	return writers, nil
+}
+
+// buildLogger creates the zerolog logger writing to w with the configured output
+// wrappers, level, timestamp, caller and sampling settings.
+func (s *Service) buildLogger(w zerolog.LevelWriter) (zerolog.Logger, error) {
+	const op errors.Op = "logging.Service.buildLogger"
+	if s.Config.WriteTimeoutMS > 0 {
+		tw := newTimeoutWriter(w, time.Duration(s.Config.WriteTimeoutMS)*time.Millisecond, writeQueueSize, &s.writeDrops)
+		s.timeoutWriters = append(s.timeoutWriters, tw)
+		w = tw
+	}
+	if s.Config.MaxLineBytes > 0 {
+		w = newMaxLineWriter(w, s.Config.MaxLineBytes)
+	}
+	if s.Config.StableFieldOrder {
+		w = newFieldOrderWriter(w, s.Config.clone().FieldOrder)
+	}
+	if s.Config.DedupeWindowMS > 0 {
+		dw := newDedupeWriter(w, time.Duration(s.Config.DedupeWindowMS)*time.Millisecond)
+		s.dedupeWriters = append(s.dedupeWriters, dw)
+		w = dw
+	}
+	w = &routeWriter{w: w, service: s}
+	w = &captureWriter{w: w, service: s}
+	logger := zerolog.New(w).Hook(dynamicFieldsHook{service: s})
+	if s.Config.ServiceIdentity != emptyString || s.Config.IncludePID || s.Config.IncludeHost {
+		ctx := logger.With()
+		if s.Config.ServiceIdentity != emptyString {
+			ctx = ctx.Str(serviceFieldName, s.Config.ServiceIdentity)
+		}
+		if s.Config.IncludePID {
+			ctx = ctx.Int(pidFieldName, os.Getpid())
+		}
+		if s.Config.IncludeHost {
+			if host, err := cachedHostname(); err == nil {
+				ctx = ctx.Str(hostFieldName, host)
+			}
 		}
+		logger = ctx.Logger()
+	}
+
+	level, levelErr := parseLevel(s.LoggingConfig.Level)
+	if levelErr != nil {
+		return logger, errors.New(op).Errorf("parseLevel: %w", levelErr)
+	}
+	logger = logger.Level(s.quietLevel(level))
+
+	if s.LoggingConfig.WithTimestamp {
+		// A hook rather than zerolog's Timestamp() so that SetClock applies
+		logger = logger.Hook(timestampHook{utc: s.Config.TimestampUTC, format: s.Config.TimestampFormat, clock: &s.clock})
+	}
 
-		if s.LoggingConfig.SkipFrameCount > 0 {
+	if s.Config.IncludeGoroutineID {
+		logger = logger.Hook(goroutineIDHook{})
+	}
+
+	if s.Config.EmitNumericSeverity {
+		logger = logger.Hook(severityHook{})
+	}
+
+	if s.LoggingConfig.SkipFrameCount > 0 {
+		if s.Config.CallerTrimPrefix != emptyString || s.Config.CallerTrimAuto {
+			logger = logger.Hook(callerHook{
+				skip:       s.LoggingConfig.SkipFrameCount,
+				trimPrefix: s.Config.CallerTrimPrefix,
+				auto:       s.Config.CallerTrimAuto,
+			})
+		} else {
 			logger = logger.With().CallerWithSkipFrameCount(s.LoggingConfig.SkipFrameCount).Logger()
 		}
+	}
 
-		// Store logger atomically
-		s.logger.Store(&logger)
+	// In first-per-message mode sampling happens at Msg time instead. Fatal, panic
+	// and audit events drop the sampler again when they are created
+	if s.Config.SampleBurst > 0 && s.Config.SampleMode != SampleModeFirstPerMessage {
+		logger = logger.Sample(s.newSampler())
+	}
 
-		s.isInitialized.Store(true)
-	})
+	return logger, nil
+}
 
-	return s.initErr
+// cachedHostname returns os.Hostname, resolved once per process.
+var cachedHostname = sync.OnceValues(os.Hostname)
+
+// newSampler returns the burst sampler described by SampleBurst and SamplePeriodMS.
+func (s *Service) newSampler() zerolog.Sampler {
+	return &zerolog.BurstSampler{
+		Burst:  uint32(s.Config.SampleBurst),
+		Period: time.Duration(s.Config.SamplePeriodMS) * time.Millisecond,
+	}
+}
+
+// SetOutput redirects the logger to w without re-initializing the service. The
+// new logger keeps the configured level, timestamp, caller and sampling settings.
+// Events created before the switch, and context loggers created with With()
+// before it, keep writing to the previous output, which stays open until Close.
+// Writing to anything other than the log file detaches file rotation (and the
+// write error reporting and stderr fallback of the file writer); the file
+// itself is still closed by Close.
+func (s *Service) SetOutput(w io.Writer) error {
+	const op errors.Op = "logging.Service.SetOutput"
+	if s == nil {
+		return errors.New(op).Msg(errMsgNilService)
+	}
+	if w == nil {
+		return errors.New(op).Msg("writer is nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.isInitialized.Load() {
+		return errors.New(op).Msg("logging service is not initialized")
+	}
+
+	logger, err := s.buildLogger(zerolog.MultiLevelWriter(w))
+	if err != nil {
+		return errors.New(op).Err(err).Msg("failed to build logger")
+	}
+	s.logger.Store(&logger)
+	return nil
 }
 
 // Close stops accepting new log operations, waits for in-flight logging to
 // finish up to a configured timeout, optionally warns on timeout, and closes
 // any open file writer. It is safe to call multiple times.
+// Close is equivalent to CloseCtx with a context that expires after ShutdownTimeoutMS.
 func (s *Service) Close() error {
+	if s == nil {
+		return nil
+	}
+	if !s.isInitialized.Load() {
+		return nil
+	}
+
+	// Determine timeout (default 100ms if not configured)
+	timeout := 100 * time.Millisecond
+	if s.LoggingConfig != nil && s.LoggingConfig.ShutdownTimeoutMS > 0 {
+		timeout = time.Duration(s.LoggingConfig.ShutdownTimeoutMS) * time.Millisecond
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return s.closeCtx(ctx, timeout)
+}
+
+// CloseCtx behaves like Close but waits for in-flight logging until ctx is done
+// instead of using ShutdownTimeoutMS. This lets a shutdown orchestrator impose its
+// own deadline. When ctx is done before in-flight operations drain, the same
+// timeout warning as Close is emitted (if ShutdownTimeoutWarning is enabled).
+func (s *Service) CloseCtx(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	var timeout time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	return s.closeCtx(ctx, timeout)
+}
+
+// OnShutdownTimeout registers a callback invoked when Close or CloseCtx gives up
+// waiting for in-flight log operations, with the number still active. It runs
+// before the timeout warning is written; a panic inside it is recovered. Passing
+// nil removes the callback.
+func (s *Service) OnShutdownTimeout(fn func(active int32)) {
+	if s == nil {
+		return
+	}
+	if fn == nil {
+		s.onShutdownTimeout.Store(nil)
+		return
+	}
+	s.onShutdownTimeout.Store(&fn)
+}
+
+// reportShutdownTimeout invokes the shutdown timeout callback, if any.
+func (s *Service) reportShutdownTimeout(active int32) {
+	fn := s.onShutdownTimeout.Load()
+	if fn == nil {
+		return
+	}
+	defer func() {
+		// A misbehaving callback must not prevent the writers from being closed
+		_ = recover()
+	}()
+	(*fn)(active)
+}
+
+// closeCtx implements Close and CloseCtx. timeout is only used for reporting.
+func (s *Service) closeCtx(ctx context.Context, timeout time.Duration) error {
 	const op errors.Op = "logging.Service.Close"
 	if s == nil {
 		return nil
@@ -136,6 +422,10 @@ func (s *Service) Close() error {
 		return nil
 	}
 
+	// Final accumulator and breaker summaries need the logger, so write them first
+	s.stopAccumulators()
+	s.closeBreakers()
+
 	// Lock to prevent concurrent logging operations during close
 	s.mu.Lock()
 
@@ -153,19 +443,15 @@ func (s *Service) Close() error {
 	s.logger.Store(nil)
 	s.mu.Unlock()
 
-	// Determine timeout (default 100ms if not configured)
-	timeoutMS := 100
 	warnOnTimeout := false
 	if s.LoggingConfig != nil {
-		if s.LoggingConfig.ShutdownTimeoutMS > 0 {
-			timeoutMS = s.LoggingConfig.ShutdownTimeoutMS
-		}
 		warnOnTimeout = s.LoggingConfig.ShutdownTimeoutWarning
 	}
 
-	// Wait for active logging operations to complete using WaitGroup with timeout
-	if waitTimeout(&s.wg, time.Duration(timeoutMS)*time.Millisecond) {
+	// Wait for active logging operations to complete using WaitGroup until ctx is done
+	if waitContext(ctx, &s.wg) {
 		// Timed out
+		s.reportShutdownTimeout(s.activeOps.Load())
 		if warnOnTimeout && logger != nil {
 			activeOps := s.activeOps.Load()
 
@@ -178,8 +464,13 @@ func (s *Service) Close() error {
 			s.mu.Unlock()
 
 			event := logger.Warn().
-				Int32("active_operations", activeOps).
-				Int("timeout_ms", timeoutMS)
+				Int32("active_operations", activeOps)
+			if timeout > 0 {
+				event = event.Int64("timeout_ms", timeout.Milliseconds())
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				event = event.Str("reason", ctxErr.Error())
+			}
 
 			// Add location info if available
 			if len(locations) > 0 {
@@ -191,8 +482,7 @@ func (s *Service) Close() error {
 			// Force-drain the WaitGroup to prevent indefinite blocking
 			// This handles orphaned log operations that never called Msg()/Send()
 			for i := int32(0); i < activeOps; i++ {
-				s.activeOps.Add(-1)
-				s.wg.Done()
+				s.releaseOp(emptyString)
 			}
 		}
 	}
@@ -203,20 +493,68 @@ func (s *Service) Close() error {
 	s.mu.Lock()
 	fileWriter := s.fileWriter
 	s.fileWriter = nil
+	bufWriter := s.bufWriter
+	s.bufWriter = nil
+	errFileWriter := s.errFileWriter
+	s.errFileWriter = nil
+	timeoutWriters := s.timeoutWriters
+	s.timeoutWriters = nil
+	dedupeWriters := s.dedupeWriters
+	s.dedupeWriters = nil
+	fifoWriter := s.fifoWriter
+	s.fifoWriter = nil
 	s.mu.Unlock()
 
+	// Report duplicates still pending; they pass through the timeout writers
+	for _, dw := range dedupeWriters {
+		_ = dw.flush()
+	}
+
+	// Write out lines still queued for slow outputs before the files are closed,
+	// dropping the rest once ctx is done
+	for _, tw := range timeoutWriters {
+		tw.Close(ctx)
+	}
+
+	// Final flush of buffered lines now that in-flight operations have drained
+	if bufWriter != nil {
+		if err := bufWriter.Close(); err != nil {
+			return errors.New(op).Errorf("bufWriter.Close: %w", err)
+		}
+	}
+
 	if fileWriter != nil {
 		if err := fileWriter.Close(); err != nil {
 			return errors.New(op).Errorf("fileWriter.Close: %w", err)
 		}
 	}
 
+	if errFileWriter != nil {
+		if err := errFileWriter.Close(); err != nil {
+			return errors.New(op).Errorf("errFileWriter.Close: %w", err)
+		}
+	}
+
+	if fifoWriter != nil {
+		if err := fifoWriter.Close(); err != nil {
+			return errors.New(op).Errorf("fifoWriter.Close: %w", err)
+		}
+	}
+
+	s.closeSubscribers()
+
+	if router := s.fieldRoute.Swap(nil); router != nil {
+		if err := router.close(); err != nil {
+			return errors.New(op).Errorf("router.close: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// waitTimeout waits for the waitgroup for the specified duration.
-// Returns true if waiting timed out.
-func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+// waitContext waits for the waitgroup until ctx is done.
+// Returns true if ctx was done before the waitgroup drained.
+func waitContext(ctx context.Context, wg *sync.WaitGroup) bool {
 	c := make(chan struct{})
 	go func() {
 		defer close(c)
@@ -225,8 +563,14 @@ func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
 	select {
 	case <-c:
 		return false // completed normally
-	case <-time.After(timeout):
-		return true // timed out
+	case <-ctx.Done():
+		// Prefer reporting success if the waitgroup drained at the same time
+		select {
+		case <-c:
+			return false
+		default:
+			return true // timed out or cancelled
+		}
 	}
 }
 
@@ -239,6 +583,15 @@ func (s *Service) Wait() {
 	s.wg.Wait()
 }
 
+// Name returns Config.ServiceIdentity, the value of the service field on every
+// line, or an empty string if it is not set.
+func (s *Service) Name() string {
+	if s == nil {
+		return emptyString
+	}
+	return s.Config.ServiceIdentity
+}
+
 // ActiveOperations returns the current number of active logging operations.
 // This is primarily used by shutdown logic to wait for in-flight operations to complete.
 func (s *Service) ActiveOperations() int32 {
@@ -248,6 +601,81 @@ func (s *Service) ActiveOperations() int32 {
 	return s.activeOps.Load()
 }
 
+// PeakActiveOperations returns the highest number of concurrently active logging
+// operations observed since initialization or the last ResetPeak. A peak that
+// keeps growing while ActiveOperations stays high indicates leaked events that
+// never call Msg/Msgf/Send.
+func (s *Service) PeakActiveOperations() int32 {
+	if s == nil {
+		return 0
+	}
+	return s.peakOps.Load()
+}
+
+// ResetPeak resets the peak gauge to the current number of active operations.
+func (s *Service) ResetPeak() {
+	if s == nil {
+		return
+	}
+	s.peakOps.Store(s.activeOps.Load())
+}
+
+// trackOp registers a new in-flight logging operation for shutdown tracking and
+// updates the peak gauge. Every call must be balanced by a releaseOp.
+func (s *Service) trackOp() {
+	// The WaitGroup is incremented first so that its counter never drops below
+	// activeOps, which releaseOp relies on
+	s.wg.Add(1)
+	n := s.activeOps.Add(1)
+	for {
+		peak := s.peakOps.Load()
+		if n <= peak || s.peakOps.CompareAndSwap(peak, n) {
+			return
+		}
+	}
+}
+
+// releaseOp balances a trackOp: it decrements activeOps and the WaitGroup, and
+// the location counter if ShutdownTimeoutWarning tracking recorded one. A
+// release without a matching trackOp (a double release bug) would drive the
+// WaitGroup negative and panic the application, so it is refused instead and
+// reported by reportUnbalancedRelease.
+func (s *Service) releaseOp(location string) {
+	for {
+		n := s.activeOps.Load()
+		if n <= 0 {
+			s.reportUnbalancedRelease()
+			return
+		}
+		if s.activeOps.CompareAndSwap(n, n-1) {
+			break
+		}
+	}
+	s.wg.Done()
+
+	if location != emptyString {
+		s.mu.Lock()
+		if s.activeOpLocations != nil {
+			s.activeOpLocations[location]--
+			if s.activeOpLocations[location] <= 0 {
+				delete(s.activeOpLocations, location)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// unbalancedReleaseNotice is written by reportUnbalancedRelease.
+const unbalancedReleaseNotice = "logging: internal warning: logging operation released more often than tracked; ignoring the extra release\n"
+
+// reportUnbalancedRelease counts a refused release and writes
+// unbalancedReleaseNotice to stderr the first time.
+func (s *Service) reportUnbalancedRelease() {
+	if s.unbalancedReleases.Inc() == 1 {
+		_, _ = io.WriteString(s.stderrOut(), unbalancedReleaseNotice)
+	}
+}
+
 // TraceWith returns a LogEvent for structured Trace-level logging.
 // Trace is the most verbose logging level, typically used for very detailed debugging.
 func (s *Service) TraceWith() LogEvent {
@@ -289,6 +717,43 @@ func (s *Service) PanicWith() LogEvent {
 	return logEventBuilder(s, zerolog.PanicLevel)
 }
 
+// WithComponent returns a context logger tagged with the given component name.
+// It is equivalent to With().Str(key, name).Logger(), where key is
+// Config.ComponentKey or "component" if unset.
+func (s *Service) WithComponent(name string) Logger {
+	if s == nil {
+		return &noopLogger{}
+	}
+	key := s.Config.ComponentKey
+	if key == emptyString {
+		key = defaultComponentKey
+	}
+	return s.With().Str(key, name).Logger()
+}
+
+// Zerolog returns the underlying zerolog logger for integrations that require a
+// *zerolog.Logger. It is never nil: a disabled logger is returned if the service
+// is not initialized. Events created through it bypass the service's lifecycle
+// tracking, so Close does not wait for them, and they must not be used after Close.
+// The pointer is shared with the service; derive a new logger instead of
+// modifying it in place.
+func (s *Service) Zerolog() *zerolog.Logger {
+	if s == nil || !s.isInitialized.Load() {
+		nop := zerolog.Nop()
+		return &nop
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	logger := s.logger.Load()
+	if logger == nil || !s.isInitialized.Load() {
+		nop := zerolog.Nop()
+		return &nop
+	}
+	return logger
+}
+
 // With returns a LogContext for creating a child logger with pre-populated fields.
 // Example: reqLogger := logger.With().Str("request_id", id).Logger()
 // Returns a no-op context if the service is not initialized.
@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Station-Manager/logging/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SetOutput(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.Level = "info"
+	cfg.WithTimestamp = true
+	service, dir := newFileTestService(t, cfg, Config{})
+
+	service.InfoWith().Msg("to file")
+
+	var buf threadSafeBuffer
+	require.NoError(t, service.SetOutput(&buf))
+	service.DebugWith().Msg("below level")
+	service.InfoWith().Str("k", "v").Msg("to buffer")
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "to file", entries[0]["message"])
+
+	lines, err := logtest.DecodeLines(strings.NewReader(buf.String()))
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	logtest.AssertField(t, []byte(buf.String()), "message", "to buffer")
+	logtest.AssertField(t, []byte(buf.String()), "k", "v")
+	assert.Contains(t, lines[0], "time", "timestamp setting is preserved")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestService_SetOutputErrors(t *testing.T) {
+	var nilService *Service
+	assert.Error(t, nilService.SetOutput(&threadSafeBuffer{}))
+	assert.Error(t, (&Service{}).SetOutput(&threadSafeBuffer{}))
+
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+	assert.Error(t, service.SetOutput(nil))
+}
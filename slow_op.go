@@ -0,0 +1,26 @@
+package logging
+
+import "time"
+
+// SlowOp starts timing the named operation and returns a stop function. When
+// stop is called and at least threshold has elapsed, a Warn line is written with
+// operation, elapsed_ms and threshold_ms; faster operations log nothing. The
+// returned function is always non-nil and is a no-op on a nil service.
+// Example: defer svc.SlowOp("db.query", 200*time.Millisecond)()
+func (s *Service) SlowOp(name string, threshold time.Duration) func() {
+	if s == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+		if elapsed < threshold {
+			return
+		}
+		s.WarnWith().
+			Str("operation", name).
+			Dur("elapsed_ms", elapsed).
+			Dur("threshold_ms", threshold).
+			Msg("slow operation")
+	}
+}
@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SlowOp(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	stop := service.SlowOp("fast", time.Hour)
+	stop()
+
+	stop = service.SlowOp("slow", 5*time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	stop()
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "warn", entries[0]["level"])
+	assert.Equal(t, "slow", entries[0]["operation"])
+	assert.GreaterOrEqual(t, entries[0]["elapsed_ms"], float64(5))
+	assert.Equal(t, float64(5), entries[0]["threshold_ms"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestService_SlowOpUninitialized(t *testing.T) {
+	var nilService *Service
+	assert.NotPanics(t, nilService.SlowOp("x", 0))
+	assert.NotPanics(t, (&Service{}).SlowOp("x", 0))
+}
@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+// Span times a unit of work between a "span start" and a "span end" line that
+// share a span_id, for tracing a request through the logs without a tracing
+// system. It is safe for concurrent use.
+// Example:
+//
+//	span := svc.StartSpan("upload")
+//	defer span.End()
+//	if err := upload(); err != nil { span.Error(err) }
+type Span struct {
+	service *Service
+	name    string
+	id      string
+	start   time.Time
+	ended   atomic.Bool
+
+	mu  sync.Mutex
+	err error
+}
+
+// StartSpan writes a Debug "span start" line with span (the name) and a newly
+// generated span_id, and returns the Span to end.
+func (s *Service) StartSpan(name string) *Span {
+	span := &Span{service: s, name: name, id: newSpanID(), start: s.now()}
+	s.DebugWith().Str("span", name).Str("span_id", span.id).Msg("span start")
+	return span
+}
+
+// newSpanID returns 16 random hex characters.
+func newSpanID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// ID returns the span_id, e.g. to pass it on to another service.
+func (sp *Span) ID() string {
+	return sp.id
+}
+
+// Error marks the span as failed with err; End then logs at Error level. The
+// first non-nil error is kept.
+func (sp *Span) Error(err error) {
+	if err == nil {
+		return
+	}
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	if sp.err == nil {
+		sp.err = err
+	}
+}
+
+// End writes the "span end" line with span, span_id and elapsed_ms: at Debug
+// level, or at Error level with failed=true and the error if Error was called.
+// Only the first call logs.
+func (sp *Span) End() {
+	if !sp.ended.CompareAndSwap(false, true) {
+		return
+	}
+	elapsed := sp.service.now().Sub(sp.start)
+	sp.mu.Lock()
+	err := sp.err
+	sp.mu.Unlock()
+
+	if err != nil {
+		sp.service.ErrorWith().
+			Str("span", sp.name).
+			Str("span_id", sp.id).
+			Dur("elapsed_ms", elapsed).
+			Bool("failed", true).
+			Err(err).
+			Msg("span end")
+		return
+	}
+	sp.service.DebugWith().
+		Str("span", sp.name).
+		Str("span_id", sp.id).
+		Dur("elapsed_ms", elapsed).
+		Msg("span end")
+}
@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartSpan(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	span := service.StartSpan("upload")
+	time.Sleep(2 * time.Millisecond)
+	span.End()
+	span.End()
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+
+	start, end := entries[0], entries[1]
+	assert.Equal(t, "span start", start["message"])
+	assert.Equal(t, "debug", start["level"])
+	assert.Equal(t, "upload", start["span"])
+	assert.Len(t, start["span_id"], 16)
+
+	assert.Equal(t, "span end", end["message"])
+	assert.Equal(t, "debug", end["level"])
+	assert.Equal(t, start["span_id"], end["span_id"])
+	assert.Equal(t, span.ID(), end["span_id"])
+	assert.Greater(t, end["elapsed_ms"], float64(0))
+	assert.NotContains(t, end, "failed")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestStartSpan_Error(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	span := service.StartSpan("import")
+	span.Error(nil)
+	span.Error(errors.New("disk full"))
+	span.Error(errors.New("second"))
+	span.End()
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	end := entries[1]
+	assert.Equal(t, "error", end["level"])
+	assert.Equal(t, true, end["failed"])
+	assert.Equal(t, "disk full", end["error"])
+	assert.Equal(t, entries[0]["span_id"], end["span_id"])
+}
+
+func TestStartSpan_UniqueIDs(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+	assert.NotEqual(t, service.StartSpan("a").ID(), service.StartSpan("b").ID())
+
+	var nilService *Service
+	assert.NotPanics(t, func() { nilService.StartSpan("x").End() })
+}
+
+func TestStartSpan_UsesServiceClock(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.SetClock(func() time.Time { return now })
+
+	span := service.StartSpan("upload")
+	now = now.Add(1500 * time.Millisecond)
+	span.End()
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, float64(1500), entries[1]["elapsed_ms"])
+}
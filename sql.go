@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"context"
+	"time"
+)
+
+// sqlStartKey is the context key under which SQLHooks.Before stores the start
+// time of a query.
+type sqlStartKey struct{}
+
+// SQLHooks logs database/sql queries. Its Before, After and OnError methods
+// match the Hooks and OnErrorer interfaces of github.com/qustavo/sqlhooks, so
+// it can be passed to sqlhooks.Wrap (or called from any driver wrapper that
+// runs hooks around queries). Successful queries are logged at Debug with
+// query, duration_ms and arg_count; failed ones at Error with the error and its
+// chain enrichment. Argument values are not logged unless ShowArgs is set.
+type SQLHooks struct {
+	service *Service
+
+	// ShowArgs adds the argument values as sql_args. Leave it off when
+	// arguments may hold credentials or personal data.
+	ShowArgs bool
+}
+
+// SQLLogger returns query logging hooks for a database/sql driver wrapper.
+// Example: sql.Register("sqlite-logged", sqlhooks.Wrap(&sqlite3.SQLiteDriver{}, svc.SQLLogger()))
+func (s *Service) SQLLogger() SQLHooks {
+	return SQLHooks{service: s}
+}
+
+// Before records the start time of the query in the returned context.
+func (h SQLHooks) Before(ctx context.Context, _ string, _ ...interface{}) (context.Context, error) {
+	return context.WithValue(ctx, sqlStartKey{}, time.Now()), nil
+}
+
+// After logs the completed query at Debug level.
+func (h SQLHooks) After(ctx context.Context, query string, args ...interface{}) (context.Context, error) {
+	h.fields(h.service.DebugWith(), ctx, query, args).Msg("sql query")
+	return ctx, nil
+}
+
+// OnError logs the failed query at Error level and returns err unchanged.
+func (h SQLHooks) OnError(ctx context.Context, err error, query string, args ...interface{}) error {
+	h.fields(h.service.ErrorWith(), ctx, query, args).Err(err).Msg("sql query failed")
+	return err
+}
+
+// fields adds the query fields to event.
+func (h SQLHooks) fields(event LogEvent, ctx context.Context, query string, args []interface{}) LogEvent {
+	event = event.Str("query", query).Int("arg_count", len(args))
+	if start, ok := ctx.Value(sqlStartKey{}).(time.Time); ok {
+		event = event.Dur("duration_ms", time.Since(start))
+	}
+	if h.ShowArgs {
+		event = event.Interface("sql_args", args)
+	}
+	return event
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	stderrs "errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errMockQuery = stderrs.New("no such table: contacts")
+
+// hookedConnector is a mock database/sql driver that runs SQLHooks around
+// every Exec like a driver wrapper would. Queries containing "missing" fail.
+type hookedConnector struct {
+	hooks SQLHooks
+}
+
+func (c hookedConnector) Connect(context.Context) (driver.Conn, error) { return hookedConn(c), nil }
+func (c hookedConnector) Driver() driver.Driver                        { return nil }
+
+type hookedConn struct {
+	hooks SQLHooks
+}
+
+func (hookedConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (hookedConn) Close() error                        { return nil }
+func (hookedConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c hookedConn) ExecContext(ctx context.Context, query string, named []driver.NamedValue) (driver.Result, error) {
+	args := make([]interface{}, len(named))
+	for i, nv := range named {
+		args[i] = nv.Value
+	}
+	ctx, err := c.hooks.Before(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(query, "missing") {
+		return nil, c.hooks.OnError(ctx, errMockQuery, query, args...)
+	}
+	if _, err = c.hooks.After(ctx, query, args...); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func TestService_SQLLogger(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	db := sql.OpenDB(hookedConnector{hooks: service.SQLLogger()})
+	t.Cleanup(func() { _ = db.Close() })
+
+	const update = "UPDATE contacts SET call = ? WHERE id = ?"
+	_, err := db.Exec(update, "M0ABC", 42)
+	require.NoError(t, err)
+	_, err = db.Exec("DELETE FROM missing WHERE id = ?", 7)
+	require.ErrorIs(t, err, errMockQuery)
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "debug", entries[0]["level"])
+	assert.Equal(t, "sql query", entries[0]["message"])
+	assert.Equal(t, update, entries[0]["query"])
+	assert.Equal(t, float64(2), entries[0]["arg_count"])
+	assert.Contains(t, entries[0], "duration_ms")
+	assert.NotContains(t, entries[0], "sql_args")
+
+	assert.Equal(t, "error", entries[1]["level"])
+	assert.Equal(t, "sql query failed", entries[1]["message"])
+	assert.Equal(t, errMockQuery.Error(), entries[1]["error"])
+	assert.Equal(t, errMockQuery.Error(), entries[1]["error_root"])
+	assert.Equal(t, float64(1), entries[1]["arg_count"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestService_SQLLogger_ShowArgs(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	hooks := service.SQLLogger()
+	hooks.ShowArgs = true
+	db := sql.OpenDB(hookedConnector{hooks: hooks})
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err := db.Exec("UPDATE contacts SET call = ?", "M0ABC")
+	require.NoError(t, err)
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, []any{"M0ABC"}, entries[0]["sql_args"])
+}
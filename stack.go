@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// maxSpawnStackFrames bounds the stack captured by WithCallerStack.
+const maxSpawnStackFrames = 32
+
+// WithCallerStack captures the current call stack and returns a context logger
+// that carries it as spawn_stack on every line, formatted innermost first as
+// "function (file.go:line)". skip is the number of additional frames to omit
+// above the caller of WithCallerStack; at most 32 frames are kept. Use it at
+// goroutine entry points so that their logs show where they were started.
+// Example:
+//
+//	log := svc.WithCallerStack(0)
+//	go func() { log.InfoWith().Msg("worker started") }()
+func (s *Service) WithCallerStack(skip int) Logger {
+	if s == nil || !s.isInitialized.Load() {
+		return &noopLogger{}
+	}
+	if skip < 0 {
+		skip = 0
+	}
+	return s.With().Strs("spawn_stack", callerStack(skip+3)).Logger()
+}
+
+// callerStack formats up to maxSpawnStackFrames frames, skipping the first skip
+// frames as runtime.Callers does.
+func callerStack(skip int) []string {
+	pcs := make([]uintptr, maxSpawnStackFrames)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	stack := make([]string, 0, n)
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, frame.Function+" ("+filepath.Base(frame.File)+":"+strconv.Itoa(frame.Line)+")")
+		if !more {
+			break
+		}
+	}
+	return stack
+}
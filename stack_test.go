@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_WithCallerStack(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	logger := service.WithCallerStack(0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		logger.InfoWith().Msg("worker started")
+	}()
+	<-done
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	stack, ok := entries[0]["spawn_stack"].([]any)
+	require.True(t, ok)
+	require.NotEmpty(t, stack)
+	assert.LessOrEqual(t, len(stack), maxSpawnStackFrames)
+	top := stack[0].(string)
+	assert.True(t, strings.HasPrefix(top, "github.com/Station-Manager/logging.TestService_WithCallerStack ("), top)
+	assert.Contains(t, top, "stack_test.go:")
+}
+
+func TestService_WithCallerStackUninitialized(t *testing.T) {
+	assert.IsType(t, &noopLogger{}, (&Service{}).WithCallerStack(0))
+}
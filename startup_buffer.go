@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/Station-Manager/errors"
+	"github.com/rs/zerolog"
+)
+
+// maxStartupBufferLines bounds the lines kept between StartBuffering and
+// FlushBufferTo; later lines are counted as dropped.
+const maxStartupBufferLines = 10000
+
+// startupBuffer holds copies of written lines until they are replayed.
+type startupBuffer struct {
+	mu      sync.Mutex
+	lines   [][]byte
+	dropped int
+	closed  bool
+}
+
+func (b *startupBuffer) add(p []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	if len(b.lines) >= maxStartupBufferLines {
+		b.dropped++
+		return
+	}
+	b.lines = append(b.lines, append([]byte(nil), p...))
+}
+
+// take closes the buffer and returns its contents.
+func (b *startupBuffer) take() ([][]byte, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	lines, dropped := b.lines, b.dropped
+	b.lines = nil
+	return lines, dropped
+}
+
+// captureWriter copies every line to the service's startup buffer, if one is
+// active, and to its subscribers before passing it on.
+type captureWriter struct {
+	w       zerolog.LevelWriter
+	service *Service
+}
+
+// Write implements io.Writer.
+func (cw *captureWriter) Write(p []byte) (int, error) {
+	return cw.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+func (cw *captureWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if b := cw.service.startupBuf.Load(); b != nil {
+		b.add(p)
+	}
+	cw.service.publishLine(p)
+	return cw.w.WriteLevel(level, p)
+}
+
+// StartBuffering starts keeping an in-memory copy of every written line, for
+// example while a network log sink is still connecting. Lines are still written
+// to the configured outputs as usual. At most 10000 lines are kept; the rest
+// are counted as dropped. Calling it while already buffering does nothing.
+func (s *Service) StartBuffering() {
+	if s == nil {
+		return
+	}
+	s.startupBuf.CompareAndSwap(nil, &startupBuffer{})
+}
+
+// FlushBufferTo stops buffering and writes the lines captured since
+// StartBuffering to w, in order. If lines were dropped, a final Warn line with
+// buffer_dropped is written. It returns nil if buffering was not started.
+func (s *Service) FlushBufferTo(w io.Writer) error {
+	const op errors.Op = "logging.Service.FlushBufferTo"
+	if s == nil {
+		return errors.New(op).Msg(errMsgNilService)
+	}
+	if w == nil {
+		return errors.New(op).Msg("writer is nil")
+	}
+	b := s.startupBuf.Swap(nil)
+	if b == nil {
+		return nil
+	}
+
+	lines, dropped := b.take()
+	for _, line := range lines {
+		if _, err := w.Write(line); err != nil {
+			return errors.New(op).Errorf("w.Write: %w", err)
+		}
+	}
+	if dropped > 0 {
+		notice := `{"level":"warn","buffer_dropped":` + strconv.Itoa(dropped) + `,"message":"startup buffer full, lines dropped"}` + "\n"
+		if _, err := io.WriteString(w, notice); err != nil {
+			return errors.New(op).Errorf("io.WriteString: %w", err)
+		}
+	}
+	return nil
+}
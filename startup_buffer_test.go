@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartBuffering_FlushBufferTo(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.InfoWith().Msg("before buffering")
+	service.StartBuffering()
+	for _, msg := range []string{"one", "two", "three"} {
+		service.InfoWith().Str("step", msg).Msg(msg)
+	}
+
+	var sink threadSafeBuffer
+	require.NoError(t, service.FlushBufferTo(&sink))
+	service.InfoWith().Msg("after flush")
+
+	var replayed []string
+	sc := bufio.NewScanner(strings.NewReader(sink.String()))
+	for sc.Scan() {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &entry))
+		replayed = append(replayed, entry["message"].(string))
+	}
+	assert.Equal(t, []string{"one", "two", "three"}, replayed)
+
+	// The normal output still received everything
+	entries := readLogEntries(t, dir, logFileName(service))
+	assert.Len(t, entries, 5)
+
+	// Nothing is buffered any more
+	var empty threadSafeBuffer
+	require.NoError(t, service.FlushBufferTo(&empty))
+	assert.Empty(t, empty.String())
+	require.Error(t, service.FlushBufferTo(nil))
+}
+
+func TestStartupBuffer_Bounded(t *testing.T) {
+	b := &startupBuffer{}
+	for i := 0; i < maxStartupBufferLines+3; i++ {
+		b.add([]byte("line\n"))
+	}
+	lines, dropped := b.take()
+	assert.Len(t, lines, maxStartupBufferLines)
+	assert.Equal(t, 3, dropped)
+
+	b.add([]byte("late\n"))
+	lines, _ = b.take()
+	assert.Empty(t, lines)
+}
+
+func TestFlushBufferTo_ReportsDropped(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+	service.StartBuffering()
+	b := service.startupBuf.Load()
+	require.NotNil(t, b)
+	b.dropped = 2
+
+	var sink threadSafeBuffer
+	require.NoError(t, service.FlushBufferTo(&sink))
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(sink.String()), &entry))
+	assert.Equal(t, float64(2), entry["buffer_dropped"])
+	assert.Equal(t, "warn", entry["level"])
+}
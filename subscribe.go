@@ -0,0 +1,141 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"go.uber.org/atomic"
+)
+
+// subscriberBufferSize is the number of lines a subscriber's channel holds
+// before further lines are dropped for it.
+const subscriberBufferSize = 256
+
+// subscriber receives copies of written lines; see Subscribe.
+type subscriber struct {
+	ch      chan json.RawMessage
+	dropped atomic.Int64
+
+	mu     sync.RWMutex // publish holds it for reading; close for writing
+	closed bool
+}
+
+// publish hands line to the subscriber without blocking. Lines that do not fit
+// are counted, and a notice with their number is sent ahead of the next line
+// that does.
+func (sub *subscriber) publish(line json.RawMessage) {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+	if sub.closed {
+		return
+	}
+	if n := sub.dropped.Load(); n > 0 {
+		notice := json.RawMessage(`{"level":"warn","subscriber_dropped":` + strconv.FormatInt(n, 10) + `,"message":"subscriber too slow, lines dropped"}`)
+		select {
+		case sub.ch <- notice:
+			sub.dropped.Sub(n)
+		default:
+			sub.dropped.Inc()
+			return
+		}
+	}
+	select {
+	case sub.ch <- line:
+	default:
+		sub.dropped.Inc()
+	}
+}
+
+// close closes the channel; it is safe to call multiple times.
+func (sub *subscriber) close() {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.ch)
+}
+
+// Subscribe returns a channel that receives every line written from now on, as
+// JSON, for example to stream logs to a debugging UI, and a function that
+// unsubscribes and closes the channel. The channel holds 256 lines; if the
+// consumer falls behind, further lines are dropped for it (logging never
+// blocks) and a Warn line with subscriber_dropped is delivered once it catches
+// up. All channels are closed by Close; on a service that is not initialized
+// or is closing, the returned channel is already closed.
+func (s *Service) Subscribe() (<-chan json.RawMessage, func()) {
+	sub := &subscriber{ch: make(chan json.RawMessage, subscriberBufferSize)}
+	if s == nil {
+		sub.close()
+		return sub.ch, func() {}
+	}
+
+	s.subsMu.Lock()
+	// Close marks the service uninitialized before closeSubscribers takes
+	// subsMu, so a subscriber added here is always seen and closed by it.
+	if !s.isInitialized.Load() {
+		s.subsMu.Unlock()
+		sub.close()
+		return sub.ch, func() {}
+	}
+	var subs []*subscriber
+	if cur := s.subscribers.Load(); cur != nil {
+		subs = append(subs, *cur...)
+	}
+	subs = append(subs, sub)
+	s.subscribers.Store(&subs)
+	s.subsMu.Unlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			s.removeSubscriber(sub)
+			sub.close()
+		})
+	}
+}
+
+// removeSubscriber drops sub from the copy-on-write subscriber list.
+func (s *Service) removeSubscriber(sub *subscriber) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	cur := s.subscribers.Load()
+	if cur == nil {
+		return
+	}
+	subs := make([]*subscriber, 0, len(*cur))
+	for _, other := range *cur {
+		if other != sub {
+			subs = append(subs, other)
+		}
+	}
+	s.subscribers.Store(&subs)
+}
+
+// publishLine sends a copy of p to every subscriber.
+func (s *Service) publishLine(p []byte) {
+	subs := s.subscribers.Load()
+	if subs == nil || len(*subs) == 0 {
+		return
+	}
+	line := json.RawMessage(bytes.TrimRight(append([]byte(nil), p...), "\n"))
+	for _, sub := range *subs {
+		sub.publish(line)
+	}
+}
+
+// closeSubscribers unsubscribes and closes every subscriber channel.
+func (s *Service) closeSubscribers() {
+	s.subsMu.Lock()
+	subs := s.subscribers.Swap(nil)
+	s.subsMu.Unlock()
+	if subs == nil {
+		return
+	}
+	for _, sub := range *subs {
+		sub.close()
+	}
+}
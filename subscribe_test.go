@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiveLine waits for the next line on ch and decodes it.
+func receiveLine(t *testing.T, ch <-chan json.RawMessage) logEntry {
+	t.Helper()
+	select {
+	case raw, ok := <-ch:
+		require.True(t, ok, "channel closed")
+		var entry logEntry
+		require.NoError(t, json.Unmarshal(raw, &entry))
+		return entry
+	case <-time.After(5 * time.Second):
+		t.Fatal("no line received")
+		return nil
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+
+	lines, cancel := service.Subscribe()
+	defer cancel()
+
+	service.InfoWith().Msg("one")
+	service.WarnWith().Str("k", "v").Msg("two")
+	service.With().Str("request_id", "r1").Logger().ErrorWith().Msg("three")
+
+	assert.Equal(t, "one", receiveLine(t, lines)["message"])
+	second := receiveLine(t, lines)
+	assert.Equal(t, "two", second["message"])
+	assert.Equal(t, "v", second["k"])
+	third := receiveLine(t, lines)
+	assert.Equal(t, "three", third["message"])
+	assert.Equal(t, "r1", third["request_id"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestSubscribe_CancelClosesChannel(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+
+	lines, cancel := service.Subscribe()
+	cancel()
+	cancel()
+	service.InfoWith().Msg("after cancel")
+
+	_, ok := <-lines
+	assert.False(t, ok)
+	assert.Empty(t, *service.subscribers.Load())
+}
+
+func TestSubscribe_CloseClosesChannels(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+
+	lines, cancel := service.Subscribe()
+	defer cancel()
+	service.InfoWith().Msg("before close")
+	require.NoError(t, service.Close())
+
+	assert.Equal(t, "before close", receiveLine(t, lines)["message"])
+	_, ok := <-lines
+	assert.False(t, ok)
+}
+
+func TestSubscribe_SlowConsumerDrops(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+
+	lines, cancel := service.Subscribe()
+	defer cancel()
+	fast, cancelFast := service.Subscribe()
+	defer cancelFast()
+
+	const extra = 10
+	for i := 0; i < subscriberBufferSize+extra; i++ {
+		service.InfoWith().Int("n", i).Msg("line")
+		<-fast
+	}
+
+	for i := 0; i < subscriberBufferSize; i++ {
+		assert.Equal(t, float64(i), receiveLine(t, lines)["n"])
+	}
+
+	// Once there is room again, the drop count arrives ahead of the next line
+	service.InfoWith().Msg("caught up")
+	notice := receiveLine(t, lines)
+	assert.Equal(t, "warn", notice["level"])
+	assert.Equal(t, float64(extra), notice["subscriber_dropped"])
+	assert.Equal(t, "caught up", receiveLine(t, lines)["message"])
+}
+
+func TestSubscribe_NilService(t *testing.T) {
+	var service *Service
+	lines, cancel := service.Subscribe()
+	assert.NotPanics(t, cancel)
+	_, ok := <-lines
+	assert.False(t, ok)
+}
+
+func TestSubscribe_AfterClose(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+	require.NoError(t, service.Close())
+
+	lines, cancel := service.Subscribe()
+	assert.NotPanics(t, cancel)
+	_, ok := <-lines
+	assert.False(t, ok)
+	assert.Nil(t, service.subscribers.Load())
+}
@@ -0,0 +1,158 @@
+package logging
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+)
+
+// writeQueueSize is the number of lines a timeoutWriter queues for its
+// background goroutine before Write starts waiting.
+const writeQueueSize = 1024
+
+// queuedLine is a line waiting to be written by a timeoutWriter. done, when
+// set, is closed once the line was written.
+type queuedLine struct {
+	level zerolog.Level
+	p     []byte
+	done  chan struct{}
+}
+
+// timeoutWriter hands lines to a background goroutine through a buffered
+// channel so that a slow writer does not block the logging goroutine. When the
+// queue is full, Write waits at most timeout for room and then drops the line,
+// counting it in drops. Fatal and panic lines additionally wait (up to timeout)
+// until they are written, since the process is about to exit or panic.
+type timeoutWriter struct {
+	w       zerolog.LevelWriter
+	timeout time.Duration
+	queue   chan queuedLine
+	done    chan struct{}
+	drops   *atomic.Int64
+	abandon atomic.Bool // Set by Close when its deadline expires; queued lines are dropped
+
+	mu     sync.RWMutex // Write holds it for reading; Close for writing
+	closed bool
+}
+
+// newTimeoutWriter wraps w and starts the background writer goroutine.
+func newTimeoutWriter(w zerolog.LevelWriter, timeout time.Duration, queueSize int, drops *atomic.Int64) *timeoutWriter {
+	tw := &timeoutWriter{
+		w:       w,
+		timeout: timeout,
+		queue:   make(chan queuedLine, queueSize),
+		done:    make(chan struct{}),
+		drops:   drops,
+	}
+	go tw.writeLoop()
+	return tw
+}
+
+// writeLoop writes queued lines until the queue is closed and drained.
+func (tw *timeoutWriter) writeLoop() {
+	defer close(tw.done)
+	for line := range tw.queue {
+		if tw.abandon.Load() {
+			tw.drop(line)
+			continue
+		}
+		_, _ = tw.w.WriteLevel(line.level, line.p)
+		if line.done != nil {
+			close(line.done)
+		}
+	}
+}
+
+// drop counts a queued line that will not be written.
+func (tw *timeoutWriter) drop(line queuedLine) {
+	tw.drops.Inc()
+	if line.done != nil {
+		close(line.done)
+	}
+}
+
+// Write implements io.Writer.
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	return tw.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter. It never reports an error for a
+// dropped line; see DroppedWrites.
+func (tw *timeoutWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	tw.mu.RLock()
+	defer tw.mu.RUnlock()
+	if tw.closed {
+		// The output may already be closed; writing would reopen the log file
+		tw.drops.Inc()
+		return len(p), nil
+	}
+
+	// zerolog reuses p once Write returns
+	line := queuedLine{level: level, p: append([]byte(nil), p...)}
+	if level == zerolog.FatalLevel || level == zerolog.PanicLevel {
+		line.done = make(chan struct{})
+	}
+
+	select {
+	case tw.queue <- line:
+	default:
+		timer := time.NewTimer(tw.timeout)
+		defer timer.Stop()
+		select {
+		case tw.queue <- line:
+		case <-timer.C:
+			tw.drops.Inc()
+			return len(p), nil
+		}
+	}
+
+	if line.done != nil {
+		timer := time.NewTimer(tw.timeout)
+		defer timer.Stop()
+		select {
+		case <-line.done:
+		case <-timer.C:
+		}
+	}
+	return len(p), nil
+}
+
+// Close stops accepting queued lines and waits until the queue is written or
+// ctx is done. In the latter case the lines still queued are dropped and
+// counted in drops; a write already in progress cannot be interrupted and
+// finishes in the background. Later writes are dropped and counted as well,
+// since the underlying output is closed next. It is safe to call multiple
+// times.
+func (tw *timeoutWriter) Close(ctx context.Context) {
+	tw.mu.Lock()
+	if tw.closed {
+		tw.mu.Unlock()
+		return
+	}
+	tw.closed = true
+	close(tw.queue)
+	tw.mu.Unlock()
+
+	select {
+	case <-tw.done:
+	case <-ctx.Done():
+		tw.abandon.Store(true)
+		// Drain alongside writeLoop, which drops what it still receives
+		for line := range tw.queue {
+			tw.drop(line)
+		}
+	}
+}
+
+// DroppedWrites returns the number of lines dropped because the output did
+// not accept them within Config.WriteTimeoutMS, were still queued when the
+// Close or CloseCtx deadline expired, or arrived after Close.
+func (s *Service) DroppedWrites() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.writeDrops.Load()
+}
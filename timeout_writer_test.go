@@ -0,0 +1,114 @@
+package logging
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// gatedWriter blocks every write until release is closed.
+type gatedWriter struct {
+	release chan struct{}
+	entered atomic.Bool
+	buf     threadSafeBuffer
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	g.entered.Store(true)
+	<-g.release
+	return g.buf.Write(p)
+}
+
+func TestTimeoutWriter_DropsWhenOutputIsSlow(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.Level = "info"
+	service, _ := newFileTestService(t, cfg, Config{WriteTimeoutMS: 20})
+
+	out := &gatedWriter{release: make(chan struct{})}
+	require.NoError(t, service.SetOutput(out))
+
+	// One line is held by the blocked writer and writeQueueSize are queued;
+	// the rest cannot be queued and must be dropped after the timeout
+	const extra = 5
+	total := writeQueueSize + 1 + extra
+	service.InfoWith().Int("n", 0).Msg("line")
+	require.Eventually(t, out.entered.Load, time.Second, time.Millisecond)
+
+	var slowest time.Duration
+	for i := 1; i < total; i++ {
+		start := time.Now()
+		service.InfoWith().Int("n", i).Msg("line")
+		if d := time.Since(start); d > slowest {
+			slowest = d
+		}
+	}
+	assert.Less(t, slowest, 20*time.Millisecond+250*time.Millisecond, "caller blocked beyond the write timeout")
+	assert.EqualValues(t, extra, service.DroppedWrites())
+
+	close(out.release)
+	require.NoError(t, service.Close())
+	lines := strings.Split(strings.TrimSpace(out.buf.String()), "\n")
+	assert.Len(t, lines, total-extra)
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestTimeoutWriter_PreservesOrderAndFlushesOnClose(t *testing.T) {
+	var buf threadSafeBuffer
+	var drops atomic.Int64
+	tw := newTimeoutWriter(zerolog.MultiLevelWriter(&buf), time.Second, 4, &drops)
+
+	for _, line := range []string{"a\n", "b\n", "c\n"} {
+		n, err := tw.WriteLevel(zerolog.InfoLevel, []byte(line))
+		require.NoError(t, err)
+		assert.Equal(t, len(line), n)
+	}
+	tw.Close(context.Background())
+	tw.Close(context.Background())
+	assert.Equal(t, "a\nb\nc\n", buf.String())
+	assert.Zero(t, drops.Load())
+
+	// Writes after Close are dropped, not passed to the closed output
+	_, err := tw.Write([]byte("d\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\nc\n", buf.String())
+	assert.Equal(t, int64(1), drops.Load())
+}
+
+func TestTimeoutWriter_FatalWaitsForWrite(t *testing.T) {
+	var buf threadSafeBuffer
+	var drops atomic.Int64
+	tw := newTimeoutWriter(zerolog.MultiLevelWriter(&buf), time.Second, 4, &drops)
+	defer tw.Close(context.Background())
+
+	_, err := tw.WriteLevel(zerolog.FatalLevel, []byte("fatal\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "fatal\n", buf.String())
+}
+
+func TestTimeoutWriter_CloseStopsAtDeadline(t *testing.T) {
+	gate := &gatedWriter{release: make(chan struct{})}
+	defer close(gate.release)
+	var drops atomic.Int64
+	tw := newTimeoutWriter(zerolog.MultiLevelWriter(gate), time.Second, 8, &drops)
+
+	for i := 0; i < 4; i++ {
+		_, err := tw.WriteLevel(zerolog.InfoLevel, []byte("line\n"))
+		require.NoError(t, err)
+	}
+	require.Eventually(t, gate.entered.Load, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	tw.Close(ctx)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The line being written is still in progress; the other three are dropped
+	assert.Equal(t, int64(3), drops.Load())
+}
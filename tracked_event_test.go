@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackedEvent_NoCrossContamination(t *testing.T) {
+	var buf threadSafeBuffer
+	service := newBenchService(zerolog.DebugLevel)
+	logger := zerolog.New(&buf).Level(zerolog.DebugLevel)
+	service.logger.Store(&logger)
+	child := service.With().Str("child", "yes").Logger()
+
+	const goroutines, perGoroutine = 16, 200
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				id := fmt.Sprintf("%d-%d", g, i)
+				if i%2 == 0 {
+					service.InfoWith().Str("id", id).Int("g", g).Msg("msg " + id)
+				} else {
+					child.WarnWith().Str("id", id).Int("g", g).Msgf("msg %s", id)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, goroutines*perGoroutine)
+	for _, line := range lines {
+		var entry logEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		id := entry["id"].(string)
+		assert.Equal(t, "msg "+id, entry["message"])
+		assert.True(t, strings.HasPrefix(id, fmt.Sprintf("%v-", entry["g"])))
+		_, isChild := entry["child"]
+		assert.Equal(t, entry["level"] == "warn", isChild)
+	}
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestTrackedEvent_MsgAfterFinishIsNoop(t *testing.T) {
+	var buf threadSafeBuffer
+	service := newBenchService(zerolog.InfoLevel)
+	logger := zerolog.New(&buf)
+	service.logger.Store(&logger)
+
+	event := service.InfoWith()
+	event.Msg("once")
+	event.Msg("twice")
+	event.Send()
+
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestTrackedEvent_StaleMsgDoesNotFinalizeOtherEvents(t *testing.T) {
+	var buf threadSafeBuffer
+	service := newBenchService(zerolog.InfoLevel)
+	logger := zerolog.New(&buf)
+	service.logger.Store(&logger)
+
+	const rounds = 100
+	for i := 0; i < rounds; i++ {
+		stale := service.InfoWith()
+		stale.Msg("owner")
+		// Another event is created while the caller still holds stale
+		other := service.InfoWith().Int("i", i)
+		// Misuse: finalizing again must not touch the other event
+		stale.Msg("stale")
+		stale.Send()
+		assert.Equal(t, int32(1), service.ActiveOperations())
+		other.Msg("other")
+	}
+
+	out := buf.String()
+	assert.Equal(t, rounds, strings.Count(out, `"message":"owner"`))
+	assert.Equal(t, rounds, strings.Count(out, `"message":"other"`))
+	assert.NotContains(t, out, "stale")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+	assert.Zero(t, service.unbalancedReleases.Load())
+}
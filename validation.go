@@ -4,7 +4,6 @@ import (
 	"github.com/Station-Manager/errors"
 	"github.com/Station-Manager/types"
 	"github.com/go-playground/validator/v10"
-	"github.com/rs/zerolog"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -16,6 +15,7 @@ var once sync.Once
 // validateConfig validates the LoggingConfig structure using struct tags
 // and additional semantic checks such as a valid log level, reasonable
 // caller skip frame bounds, and RelLogFileDir safety (no traversal, relative path only).
+// A Level given as a registered alias is rewritten to its canonical name.
 func validateConfig(cfg *types.LoggingConfig) error {
 	const op errors.Op = "logging.validateConfig"
 	if cfg == nil {
@@ -26,12 +26,32 @@ func validateConfig(cfg *types.LoggingConfig) error {
 		validate = validator.New(validator.WithRequiredStructEnabled())
 	})
 
+	// Level aliases (e.g. "warning") are replaced by their canonical name so that
+	// the struct tag check below accepts them
+	cfg.Level = canonicalLevel(cfg.Level)
+
+	// Cross-field checks run first so that common mistakes get a message naming the field
+	if cfg.ShutdownTimeoutMS < 0 {
+		return errors.New(op).Msgf("ShutdownTimeoutMS cannot be negative, got %d", cfg.ShutdownTimeoutMS)
+	}
+	// With both outputs disabled, file logging is enabled by default (see initializeWriters).
+	// A zero LogFileMaxSizeMB would make lumberjack silently fall back to 100MB.
+	if fileLoggingEnabled(cfg) && cfg.LogFileMaxSizeMB <= 0 {
+		return errors.New(op).Msgf("LogFileMaxSizeMB must be > 0 when file logging is enabled, got %d", cfg.LogFileMaxSizeMB)
+	}
+	if cfg.LogFileMaxBackups < 0 {
+		return errors.New(op).Msgf("LogFileMaxBackups cannot be negative, got %d", cfg.LogFileMaxBackups)
+	}
+	if cfg.LogFileMaxAgeDays < 0 {
+		return errors.New(op).Msgf("LogFileMaxAgeDays cannot be negative, got %d", cfg.LogFileMaxAgeDays)
+	}
+
 	if err := validate.Struct(cfg); err != nil {
 		return errors.New(op).Err(err).Msg(errMsgConfigInvalid)
 	}
 
 	// Validate log level
-	if _, err := zerolog.ParseLevel(cfg.Level); err != nil {
+	if _, err := parseLevel(cfg.Level); err != nil {
 		return errors.New(op).Errorf("invalid log level '%s': %w", cfg.Level, err)
 	}
 
@@ -58,3 +78,95 @@ func validateConfig(cfg *types.LoggingConfig) error {
 
 	return nil
 }
+
+// fileLoggingEnabled reports whether cfg results in a log file: FileLogging is
+// set, or both outputs are disabled and file logging is used as the default.
+func fileLoggingEnabled(cfg *types.LoggingConfig) bool {
+	return cfg.FileLogging || !cfg.ConsoleLogging
+}
+
+// validateLocalConfig validates the package-local Config settings that
+// complement types.LoggingConfig.
+func validateLocalConfig(cfg *Config) error {
+	const op errors.Op = "logging.validateLocalConfig"
+	if cfg == nil {
+		return errors.New(op).Msg(errMsgNilConfig)
+	}
+
+	if _, ok := parseErrorEnrichment(cfg.ErrorEnrichment); !ok {
+		return errors.New(op).Msgf("ErrorEnrichment must be one of '%s', '%s' or '%s', got '%s'",
+			ErrorEnrichmentFull, ErrorEnrichmentRootOnly, ErrorEnrichmentOff, cfg.ErrorEnrichment)
+	}
+
+	if cfg.FlushIntervalMS < 0 {
+		return errors.New(op).Msg("FlushIntervalMS cannot be negative")
+	}
+
+	if cfg.FifoPath != emptyString && !fifoSupported {
+		return errors.New(op).Msg("FifoPath is only supported on Unix")
+	}
+
+	if cfg.WriteTimeoutMS < 0 {
+		return errors.New(op).Msg("WriteTimeoutMS cannot be negative")
+	}
+
+	if cfg.SampleBurst < 0 || cfg.SamplePeriodMS < 0 {
+		return errors.New(op).Msg("SampleBurst and SamplePeriodMS cannot be negative")
+	}
+	if cfg.SampleBurst > 0 && cfg.SamplePeriodMS == 0 {
+		return errors.New(op).Msg("SamplePeriodMS is required when SampleBurst is set")
+	}
+	if cfg.SamplePeriodMS > 0 && cfg.SampleBurst == 0 {
+		return errors.New(op).Msg("SampleBurst is required when SamplePeriodMS is set")
+	}
+
+	switch cfg.SampleMode {
+	case emptyString, SampleModeBurst:
+	case SampleModeFirstPerMessage:
+		if cfg.SampleBurst == 0 {
+			return errors.New(op).Msgf("SampleMode '%s' requires SampleBurst and SamplePeriodMS", cfg.SampleMode)
+		}
+	default:
+		return errors.New(op).Msgf("SampleMode must be '%s' or '%s', got '%s'",
+			SampleModeBurst, SampleModeFirstPerMessage, cfg.SampleMode)
+	}
+
+	if cfg.ConsoleMinLevel != emptyString {
+		if _, err := parseLevel(cfg.ConsoleMinLevel); err != nil {
+			return errors.New(op).Errorf("ConsoleMinLevel: %w", err)
+		}
+	}
+
+	if cfg.FatalExitCode < 0 || cfg.FatalExitCode > 255 {
+		return errors.New(op).Msgf("FatalExitCode must be between 0 and 255, got %d", cfg.FatalExitCode)
+	}
+
+	if cfg.MaxLineBytes != 0 && cfg.MaxLineBytes < minMaxLineBytes {
+		return errors.New(op).Msgf("MaxLineBytes must be 0 or at least %d, got %d", minMaxLineBytes, cfg.MaxLineBytes)
+	}
+
+	if cfg.LogFileName != emptyString {
+		if strings.ContainsAny(cfg.LogFileName, `/\`) || strings.Contains(cfg.LogFileName, "..") ||
+			cfg.LogFileName == "." {
+			return errors.New(op).Msgf("LogFileName must be a plain file name, got %q", cfg.LogFileName)
+		}
+		if cfg.ErrorFileEnabled && cfg.LogFileName == errorLogFileName {
+			return errors.New(op).Msgf("LogFileName cannot be %q when ErrorFileEnabled is set", errorLogFileName)
+		}
+	}
+
+	if cfg.DedupeWindowMS < 0 {
+		return errors.New(op).Msg("DedupeWindowMS cannot be negative")
+	}
+	if cfg.ErrorHistoryMaxLinks < 0 {
+		return errors.New(op).Msg("ErrorHistoryMaxLinks cannot be negative")
+	}
+	if cfg.BreakerThreshold < 0 {
+		return errors.New(op).Msg("BreakerThreshold cannot be negative")
+	}
+	if cfg.BreakerWindowMS < 0 {
+		return errors.New(op).Msg("BreakerWindowMS cannot be negative")
+	}
+
+	return nil
+}
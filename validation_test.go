@@ -0,0 +1,190 @@
+package logging
+
+import (
+	stderrs "errors"
+	"testing"
+
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig_CrossField(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *types.LoggingConfig)
+		wantErr string
+	}{
+		{
+			name:   "valid baseline",
+			mutate: func(cfg *types.LoggingConfig) {},
+		},
+		{
+			name:    "negative shutdown timeout",
+			mutate:  func(cfg *types.LoggingConfig) { cfg.ShutdownTimeoutMS = -1 },
+			wantErr: "ShutdownTimeoutMS",
+		},
+		{
+			name: "file logging without max size",
+			mutate: func(cfg *types.LoggingConfig) {
+				cfg.FileLogging = true
+				cfg.LogFileMaxSizeMB = 0
+			},
+			wantErr: "LogFileMaxSizeMB",
+		},
+		{
+			name: "default file logging without max size",
+			mutate: func(cfg *types.LoggingConfig) {
+				cfg.FileLogging = false
+				cfg.ConsoleLogging = false
+				cfg.LogFileMaxSizeMB = 0
+			},
+			wantErr: "LogFileMaxSizeMB",
+		},
+		{
+			name: "negative max size",
+			mutate: func(cfg *types.LoggingConfig) {
+				cfg.FileLogging = true
+				cfg.LogFileMaxSizeMB = -5
+			},
+			wantErr: "LogFileMaxSizeMB",
+		},
+		{
+			name:    "negative max backups",
+			mutate:  func(cfg *types.LoggingConfig) { cfg.LogFileMaxBackups = -1 },
+			wantErr: "LogFileMaxBackups",
+		},
+		{
+			name:    "negative max age",
+			mutate:  func(cfg *types.LoggingConfig) { cfg.LogFileMaxAgeDays = -1 },
+			wantErr: "LogFileMaxAgeDays",
+		},
+		{
+			name: "zero backups and age keep everything",
+			mutate: func(cfg *types.LoggingConfig) {
+				cfg.FileLogging = true
+				cfg.LogFileMaxBackups = 0
+				cfg.LogFileMaxAgeDays = 0
+			},
+		},
+		{
+			name: "console only without max size",
+			mutate: func(cfg *types.LoggingConfig) {
+				cfg.FileLogging = false
+				cfg.ConsoleLogging = true
+				cfg.LogFileMaxSizeMB = 0
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validLoggingConfig()
+			tt.mutate(cfg)
+			err := validateConfig(cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateLocalConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{name: "valid baseline", cfg: Config{}},
+		{name: "valid sampling", cfg: Config{SampleBurst: 5, SamplePeriodMS: 1000}},
+		{name: "burst without period", cfg: Config{SampleBurst: 5}, wantErr: "SamplePeriodMS"},
+		{name: "period without burst", cfg: Config{SamplePeriodMS: 1000}, wantErr: "SampleBurst"},
+		{name: "negative sampling", cfg: Config{SampleBurst: -1, SamplePeriodMS: 1000}, wantErr: "cannot be negative"},
+		{name: "first-per-message sampling", cfg: Config{SampleBurst: 1, SamplePeriodMS: 1000, SampleMode: SampleModeFirstPerMessage}},
+		{name: "first-per-message without burst", cfg: Config{SampleMode: SampleModeFirstPerMessage}, wantErr: "requires SampleBurst"},
+		{name: "unknown sample mode", cfg: Config{SampleMode: "random"}, wantErr: "SampleMode"},
+		{name: "negative flush interval", cfg: Config{FlushIntervalMS: -1}, wantErr: "FlushIntervalMS"},
+		{name: "negative write timeout", cfg: Config{WriteTimeoutMS: -1}, wantErr: "WriteTimeoutMS"},
+		{name: "negative dedupe window", cfg: Config{DedupeWindowMS: -1}, wantErr: "DedupeWindowMS"},
+		{name: "negative error history max links", cfg: Config{ErrorHistoryMaxLinks: -1}, wantErr: "ErrorHistoryMaxLinks"},
+		{name: "negative breaker threshold", cfg: Config{BreakerThreshold: -1}, wantErr: "BreakerThreshold"},
+		{name: "negative breaker window", cfg: Config{BreakerWindowMS: -1}, wantErr: "BreakerWindowMS"},
+		{name: "invalid console level", cfg: Config{ConsoleMinLevel: "chatty"}, wantErr: "ConsoleMinLevel"},
+		{name: "fatal exit code out of range", cfg: Config{FatalExitCode: 256}, wantErr: "FatalExitCode"},
+		{name: "max line too small", cfg: Config{MaxLineBytes: 64}, wantErr: "MaxLineBytes"},
+		{name: "negative max line", cfg: Config{MaxLineBytes: -1}, wantErr: "MaxLineBytes"},
+		{name: "valid log file name", cfg: Config{LogFileName: "svc-7.log"}},
+		{name: "log file name with separator", cfg: Config{LogFileName: "logs/svc.log"}, wantErr: "LogFileName"},
+		{name: "log file name with traversal", cfg: Config{LogFileName: "..svc.log"}, wantErr: "LogFileName"},
+		{name: "log file name clashes with error file", cfg: Config{LogFileName: errorLogFileName, ErrorFileEnabled: true}, wantErr: "LogFileName"},
+		{name: "unknown enrichment", cfg: Config{ErrorEnrichment: "all"}, wantErr: "ErrorEnrichment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLocalConfig(&tt.cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSampling_BurstLimitsEvents(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{SampleBurst: 2, SamplePeriodMS: 60000})
+
+	for i := 0; i < 5; i++ {
+		service.InfoWith().Int("i", i).Msg("sampled")
+	}
+
+	assert.Len(t, readLogEntries(t, dir, logFileName(service)), 2)
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestSampling_FirstPerMessage(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{
+		SampleBurst:    1,
+		SamplePeriodMS: 60000,
+		SampleMode:     SampleModeFirstPerMessage,
+	})
+	errTimeout := stderrs.New("timeout")
+	errRefused := stderrs.New("connection refused")
+
+	for i := 0; i < 20; i++ {
+		service.InfoWith().Int("i", i).Msg("poll")
+	}
+	for i := 0; i < 20; i++ {
+		service.ErrorWith().Err(errTimeout).Int("i", i).Msg("request failed")
+	}
+	for i := 0; i < 20; i++ {
+		service.ErrorWith().Err(errRefused).Int("i", i).Msgf("request %s", "failed")
+	}
+
+	var firstTimeout, firstRefused bool
+	errorLines := 0
+	for _, entry := range readLogEntries(t, dir, logFileName(service)) {
+		if entry["level"] != "error" {
+			continue
+		}
+		errorLines++
+		if entry["i"] == float64(0) {
+			switch entry["error"] {
+			case "timeout":
+				firstTimeout = true
+			case "connection refused":
+				firstRefused = true
+			}
+		}
+	}
+	assert.True(t, firstTimeout, "first timeout error must be written")
+	assert.True(t, firstRefused, "first connection refused error must be written")
+	// The single burst slot went to the first info line, so only first sightings remain
+	assert.Equal(t, 2, errorLines)
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -166,6 +167,31 @@ func TestWaitGroupWithContextLoggerNilEvent(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestContextLoggerDisabledLevelNotTracked(t *testing.T) {
+	cfg := validLoggingConfig()
+	cfg.Level = "info"
+	service, dir := newFileTestService(t, cfg, Config{})
+	child := service.With().Str("component", "radio").Logger()
+
+	// A disabled event that is never finished must not hold up Close
+	pending := child.DebugWith().Str("k", "v")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+	child.TraceWith().Msg("dropped")
+	child.InfoWith().Msg("written")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+	assert.Equal(t, int32(1), service.PeakActiveOperations())
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "written", entries[0]["message"])
+
+	start := time.Now()
+	require.NoError(t, service.Close())
+	assert.Less(t, time.Since(start), time.Second)
+	pending.Msg("after close")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
 // TestWaitGroupNoLeakOnQuickShutdown simulates the exact scenario from the bug report:
 // server starts, migrations run with logging, then immediate shutdown
 func TestWaitGroupNoLeakOnQuickShutdown(t *testing.T) {
@@ -340,3 +366,136 @@ func TestNewTrackedLogEventWithNilEventOnly(t *testing.T) {
 	err := service.Close()
 	assert.NoError(t, err)
 }
+
+// TestWaitGroupBalanceWithChainedFields ensures that adding fields before Msg
+// does not bypass the tracked event's accounting
+func TestWaitGroupBalanceWithChainedFields(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+
+	service.InfoWith().Str("k", "v").Int("n", 1).Msg("chained")
+	service.ErrorWith().Err(assert.AnError).Send()
+	service.With().Str("ctx", "x").Logger().WarnWith().Bool("b", true).Msgf("chained %d", 2)
+
+	assert.Equal(t, int32(0), service.activeOps.Load(), "activeOps should be 0 after chained operations")
+}
+
+// TestPeakActiveOperations verifies the high-water mark of concurrent operations
+func TestPeakActiveOperations(t *testing.T) {
+	service, _ := newFileTestService(t, validLoggingConfig(), Config{})
+	assert.Equal(t, int32(0), service.PeakActiveOperations())
+
+	const concurrency = 8
+	var ready, release sync.WaitGroup
+	ready.Add(concurrency)
+	release.Add(1)
+	var done sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		done.Add(1)
+		go func(id int) {
+			defer done.Done()
+			// Hold the event open until all goroutines have created theirs
+			event := service.InfoWith().Int("goroutine", id)
+			ready.Done()
+			release.Wait()
+			event.Msg("concurrent")
+		}(i)
+	}
+	ready.Wait()
+	observed := service.ActiveOperations()
+	release.Done()
+	done.Wait()
+
+	assert.Equal(t, int32(concurrency), observed)
+	assert.GreaterOrEqual(t, service.PeakActiveOperations(), observed)
+	assert.GreaterOrEqual(t, service.PeakActiveOperations(), service.ActiveOperations())
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	service.ResetPeak()
+	assert.Equal(t, int32(0), service.PeakActiveOperations())
+	service.With().Logger().InfoWith().Msg("context")
+	assert.Equal(t, int32(1), service.PeakActiveOperations())
+}
+
+func TestDoubleMsgReleasesOnce(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	event := service.InfoWith().Str("k", "v")
+	event.Msg("first")
+	event.Msg("second")
+	event.Msgf("third %d", 3)
+	event.Send()
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	// Concurrent finalizers on one event: exactly one wins
+	event = service.WarnWith()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			event.Msg("racing")
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "first", entries[0]["message"])
+	assert.Equal(t, "racing", entries[1]["message"])
+
+	// Close must not block or panic on a negative WaitGroup
+	require.NoError(t, service.Close())
+}
+
+func TestDoubleMsgReleasesOnce_AnotherEventInBetween(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	event := service.InfoWith()
+	event.Msg("first")
+	// A new event between the two Msg calls must not revive the finished one
+	pending := service.WarnWith().Str("owner", "pending")
+	event.Msg("second")
+	event.Msgf("third %d", 3)
+	event.Send()
+	assert.Equal(t, int32(1), service.ActiveOperations())
+
+	pending.Msg("pending")
+	assert.Equal(t, int32(0), service.ActiveOperations())
+	assert.Zero(t, service.unbalancedReleases.Load())
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "first", entries[0]["message"])
+	assert.Equal(t, "pending", entries[1]["message"])
+	assert.Equal(t, "warn", entries[1]["level"])
+	assert.Equal(t, "pending", entries[1]["owner"])
+}
+
+// TestReleaseOp_ExtraReleaseDoesNotPanic induces a release without a matching
+// trackOp, which would otherwise drive the WaitGroup negative and panic.
+func TestReleaseOp_ExtraReleaseDoesNotPanic(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+	var stderr threadSafeBuffer
+	service.stderr = &stderr
+
+	service.InfoWith().Msg("balanced")
+	require.NotPanics(t, func() {
+		service.releaseOp(emptyString)
+		service.releaseOp(emptyString)
+	})
+
+	assert.Equal(t, int32(0), service.ActiveOperations())
+	assert.EqualValues(t, 2, service.unbalancedReleases.Load())
+	assert.Equal(t, unbalancedReleaseNotice, stderr.String(), "the warning is written once")
+
+	// Tracking still balances afterwards and Close does not hang
+	service.InfoWith().Msg("still logging")
+	service.Dump(map[string]int{"a": 1})
+	assert.Equal(t, int32(0), service.ActiveOperations())
+	name := logFileName(service)
+	require.NoError(t, service.Close())
+
+	entries := readLogEntries(t, dir, name)
+	assert.Equal(t, "still logging", entries[1]["message"])
+}
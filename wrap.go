@@ -0,0 +1,36 @@
+package logging
+
+import "github.com/Station-Manager/errors"
+
+// WrapAndLog wraps err in a DetailedError with op and msg, logs it at Error
+// level with the usual chain enrichment (see Config.ErrorEnrichment), and returns
+// the wrapped error for the caller to propagate. A nil err returns nil and logs
+// nothing.
+// Example: return svc.WrapAndLog("radio.Open", err, "failed to open radio")
+func (s *Service) WrapAndLog(op errors.Op, err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := errors.New(op).Err(err).Msg(msg)
+	s.ErrorWith().Err(wrapped).Msg(msg)
+	return wrapped
+}
+
+// Annotate wraps err from a third-party library in a DetailedError tagged op,
+// with err as its cause and err's message as its own, logs it at Error level
+// with the fields added by the fields function (which may be nil) and the usual
+// chain enrichment, and returns the wrapped error. A nil err returns nil and
+// logs nothing.
+// Example: return svc.Annotate("db.Query", err, func(e LogEvent) { e.Str("table", table) })
+func (s *Service) Annotate(op errors.Op, err error, fields func(LogEvent)) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := errors.New(op).Err(err).Msg(err.Error())
+	event := s.ErrorWith().Err(wrapped)
+	if fields != nil {
+		fields(event)
+	}
+	event.Msg(wrapped.Error())
+	return wrapped
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	smerrors "github.com/Station-Manager/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_WrapAndLog(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	assert.NoError(t, service.WrapAndLog("radio.Open", nil, "not logged"))
+
+	cause := smerrors.New("serial.Dial").Msg("port busy")
+	err := service.WrapAndLog("radio.Open", cause, "failed to open radio")
+	require.Error(t, err)
+
+	dErr, ok := smerrors.AsDetailedError(err)
+	require.True(t, ok)
+	assert.Equal(t, smerrors.Op("radio.Open"), dErr.Op())
+	assert.Equal(t, "failed to open radio", dErr.Error())
+	assert.Equal(t, cause, dErr.Cause())
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "error", entries[0]["level"])
+	assert.Equal(t, "failed to open radio", entries[0]["message"])
+	assert.Equal(t, []any{"radio.Open", "serial.Dial"}, entries[0]["error_ops"])
+	assert.Equal(t, "port busy", entries[0]["error_root"])
+	assert.Equal(t, "serial.Dial", entries[0]["error_root_op"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	// Uninitialized services still wrap
+	var nilService *Service
+	assert.Error(t, nilService.WrapAndLog("x", cause, "msg"))
+}
+
+func TestService_Annotate(t *testing.T) {
+	service, dir := newFileTestService(t, validLoggingConfig(), Config{})
+
+	assert.NoError(t, service.Annotate("db.Query", nil, nil))
+
+	cause := fmt.Errorf("query failed: %w", io.ErrUnexpectedEOF)
+	err := service.Annotate("db.Query", cause, func(e LogEvent) {
+		e.Str("table", "contacts").Int("attempt", 2)
+	})
+	require.Error(t, err)
+
+	dErr, ok := smerrors.AsDetailedError(err)
+	require.True(t, ok)
+	assert.Equal(t, smerrors.Op("db.Query"), dErr.Op())
+	assert.Equal(t, cause.Error(), dErr.Error())
+	assert.Equal(t, cause, dErr.Cause())
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	entries := readLogEntries(t, dir, logFileName(service))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "error", entries[0]["level"])
+	assert.Equal(t, cause.Error(), entries[0]["message"])
+	assert.Equal(t, "contacts", entries[0]["table"])
+	assert.Equal(t, float64(2), entries[0]["attempt"])
+	assert.Equal(t, []any{"db.Query", "", ""}, entries[0]["error_ops"])
+	assert.Equal(t, io.ErrUnexpectedEOF.Error(), entries[0]["error_root"])
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	// Nil fields and an uninitialized service still wrap
+	var nilService *Service
+	assert.Error(t, nilService.Annotate("x", cause, nil))
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// writeErrorWriter wraps a writer and reports write failures to the owning
+// Service instead of letting zerolog swallow them. If a fallback writer is set,
+// lines that fail to write are also copied to it.
+type writeErrorWriter struct {
+	w        io.Writer
+	service  *Service
+	fallback io.Writer
+}
+
+// newWriteErrorWriter wraps w so that write errors are recorded on s.
+func newWriteErrorWriter(w io.Writer, s *Service) *writeErrorWriter {
+	return &writeErrorWriter{w: w, service: s}
+}
+
+// Write implements io.Writer.
+func (ew *writeErrorWriter) Write(p []byte) (int, error) {
+	n, err := ew.w.Write(p)
+	if err != nil {
+		ew.service.reportWriteError(err)
+		if ew.fallback != nil {
+			ew.service.fallbackOnce.Do(func() {
+				_, _ = fmt.Fprintf(ew.fallback, "logging: log file write failed (%v), falling back to stderr\n", err)
+			})
+			_, _ = ew.fallback.Write(p)
+		}
+	}
+	return n, err
+}
+
+// stderrFallback returns the writer that receives lines the file writers fail
+// to write, or nil if Config.FallbackToStderr is disabled or console logging
+// already writes every line to stderr.
+func (s *Service) stderrFallback(consoleLogging bool) io.Writer {
+	if consoleLogging || (s.Config.FallbackToStderr != nil && !*s.Config.FallbackToStderr) {
+		return nil
+	}
+	return s.stderrOut()
+}
+
+// stderrOut returns the writer used for stderr output (os.Stderr unless
+// overridden in tests).
+func (s *Service) stderrOut() io.Writer {
+	if s.stderr != nil {
+		return s.stderr
+	}
+	return os.Stderr
+}
+
+// uninitializedNotice is written by warnUninitialized.
+const uninitializedNotice = "logging: service is not initialized (or already closed); log events are being dropped\n"
+
+// warnUninitialized writes uninitializedNotice to stderr once per service when
+// Config.WarnOnUninitialized is set. It never allocates after the first call.
+func (s *Service) warnUninitialized() {
+	if !s.Config.WarnOnUninitialized || !s.uninitWarned.CompareAndSwap(false, true) {
+		return
+	}
+	_, _ = io.WriteString(s.stderrOut(), uninitializedNotice)
+}
+
+// OnWriteError registers a callback invoked whenever the file writer fails to
+// write a line (for example when the disk is full). The callback runs on the
+// logging goroutine, so it should return quickly; a panic inside it is recovered.
+// Passing nil removes the callback.
+func (s *Service) OnWriteError(fn func(error)) {
+	if s == nil {
+		return
+	}
+	if fn == nil {
+		s.onWriteError.Store(nil)
+		return
+	}
+	s.onWriteError.Store(&fn)
+}
+
+// LastWriteError returns the most recent file write error, or nil if none occurred.
+func (s *Service) LastWriteError() error {
+	if s == nil {
+		return nil
+	}
+	return s.lastWriteErr.Load()
+}
+
+// reportWriteError records err and invokes the write error callback, if any.
+func (s *Service) reportWriteError(err error) {
+	s.lastWriteErr.Store(err)
+	fn := s.onWriteError.Load()
+	if fn == nil {
+		return
+	}
+	defer func() {
+		// Never let a misbehaving callback take down the logging goroutine
+		_ = recover()
+	}()
+	(*fn)(err)
+}
@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDiskFull = errors.New("no space left on device")
+
+// failingWriter always fails, simulating a full disk.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errDiskFull }
+
+func TestOnWriteError_CallbackAndLastError(t *testing.T) {
+	cfg := validLoggingConfig()
+	service := &Service{ConfigService: newTestConfigService(cfg)}
+	service.initOnce.Do(func() {
+		service.LoggingConfig = cfg
+		logger := zerolog.New(newWriteErrorWriter(failingWriter{}, service))
+		service.logger.Store(&logger)
+		service.isInitialized.Store(true)
+	})
+	defer service.Close()
+
+	assert.NoError(t, service.LastWriteError())
+
+	var got []error
+	service.OnWriteError(func(err error) {
+		got = append(got, err)
+	})
+
+	service.InfoWith().Msg("lost line")
+
+	require.Len(t, got, 1)
+	assert.ErrorIs(t, got[0], errDiskFull)
+	assert.ErrorIs(t, service.LastWriteError(), errDiskFull)
+	assert.Equal(t, int32(0), service.ActiveOperations())
+}
+
+func TestOnWriteError_PanickingCallbackRecovered(t *testing.T) {
+	service := &Service{}
+	service.OnWriteError(func(error) { panic("boom") })
+
+	w := newWriteErrorWriter(failingWriter{}, service)
+	assert.NotPanics(t, func() {
+		_, err := w.Write([]byte("x"))
+		assert.ErrorIs(t, err, errDiskFull)
+	})
+	assert.ErrorIs(t, service.LastWriteError(), errDiskFull)
+}
+
+func TestFallbackToStderr(t *testing.T) {
+	var stderr threadSafeBuffer
+	service := &Service{stderr: &stderr}
+	logger := zerolog.New(zerolog.MultiLevelWriter(
+		&writeErrorWriter{w: failingWriter{}, service: service, fallback: service.stderrFallback(false)},
+	))
+
+	logger.Info().Msg("first lost line")
+	logger.Info().Msg("second lost line")
+
+	out := stderr.String()
+	assert.Equal(t, 1, strings.Count(out, "falling back to stderr"))
+	assert.Contains(t, out, `"message":"first lost line"`)
+	assert.Contains(t, out, `"message":"second lost line"`)
+	assert.ErrorIs(t, service.LastWriteError(), errDiskFull)
+}
+
+func TestFallbackToStderr_NoCopyOnSuccess(t *testing.T) {
+	var stderr, primary threadSafeBuffer
+	service := &Service{stderr: &stderr}
+	w := &writeErrorWriter{w: &primary, service: service, fallback: service.stderrFallback(false)}
+
+	_, err := w.Write([]byte("ok\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "ok\n", primary.String())
+	assert.Empty(t, stderr.String())
+}
+
+func TestFallbackToStderr_Disabled(t *testing.T) {
+	disabled := false
+	service := &Service{stderr: &threadSafeBuffer{}, Config: Config{FallbackToStderr: &disabled}}
+	assert.Nil(t, service.stderrFallback(false))
+
+	enabled := &Service{stderr: &threadSafeBuffer{}}
+	assert.NotNil(t, enabled.stderrFallback(false))
+	// Console logging already writes every line to stderr
+	assert.Nil(t, enabled.stderrFallback(true))
+}
+
+func TestWarnOnUninitialized(t *testing.T) {
+	var stderr threadSafeBuffer
+	service := &Service{stderr: &stderr, Config: Config{WarnOnUninitialized: true}}
+
+	service.InfoWith().Msg("dropped")
+	service.ErrorWith().Str("k", "v").Msg("dropped too")
+	service.AuditWith().Msg("dropped audit")
+
+	assert.Equal(t, uninitializedNotice, stderr.String())
+	assert.Equal(t, int32(0), service.ActiveOperations())
+
+	var quiet threadSafeBuffer
+	silent := &Service{stderr: &quiet}
+	silent.InfoWith().Msg("dropped")
+	assert.Empty(t, quiet.String())
+}